@@ -70,6 +70,10 @@ const (
 	FieldAWSInstanceID
 	FieldAWSARN
 	FieldAWSTag
+	FieldUsername
+	FieldEmail
+	FieldMACAddress
+	FieldURL
 )
 
 // ScanValues implements ValueScanner interface
@@ -196,14 +200,37 @@ func init() {
 		NameJSON:    "p_any_aws_tags",
 		Description: "Panther added field with collection of AWS Tags associated with the row",
 	})
+	MustRegisterIndicator(FieldUsername, FieldMeta{
+		Name:        "PantherAnyUsernames",
+		NameJSON:    "p_any_usernames",
+		Description: "Panther added field with collection of usernames associated with the row",
+	})
+	MustRegisterIndicator(FieldEmail, FieldMeta{
+		Name:        "PantherAnyEmails",
+		NameJSON:    "p_any_emails",
+		Description: "Panther added field with collection of email addresses associated with the row",
+	})
+	MustRegisterIndicator(FieldMACAddress, FieldMeta{
+		Name:        "PantherAnyMACAddresses",
+		NameJSON:    "p_any_mac_addresses",
+		Description: "Panther added field with collection of MAC addresses associated with the row",
+	})
+	MustRegisterIndicator(FieldURL, FieldMeta{
+		Name:        "PantherAnyURLs",
+		NameJSON:    "p_any_urls",
+		Description: "Panther added field with collection of URLs associated with the row",
+	})
 	MustRegisterScanner("ip", ValueScannerFunc(ScanIPAddress), FieldIPAddress)
 	MustRegisterScanner("domain", FieldDomainName, FieldDomainName)
 	MustRegisterScanner("md5", FieldMD5Hash, FieldMD5Hash)
 	MustRegisterScanner("sha1", FieldSHA1Hash, FieldSHA1Hash)
 	MustRegisterScanner("sha256", FieldSHA256Hash, FieldSHA256Hash)
 	MustRegisterScanner("hostname", ValueScannerFunc(ScanHostname), FieldDomainName, FieldIPAddress)
-	MustRegisterScanner("url", ValueScannerFunc(ScanURL), FieldDomainName, FieldIPAddress)
+	MustRegisterScanner("url", ValueScannerFunc(ScanURL), FieldURL, FieldDomainName, FieldIPAddress)
 	MustRegisterScanner("trace_id", FieldTraceID, FieldTraceID)
+	MustRegisterScanner("username", FieldUsername, FieldUsername)
+	MustRegisterScanner("email", FieldEmail, FieldEmail)
+	MustRegisterScanner("mac", ValueScannerFunc(ScanMACAddress), FieldMACAddress)
 	MustRegisterScanner("net_addr", ValueScannerFunc(ScanNetworkAddress), FieldIPAddress, FieldDomainName)
 }
 
@@ -116,7 +116,7 @@ func LookupScanner(name string) (scanner ValueScanner, fields []FieldID) {
 	return
 }
 
-// ScanURL scans a URL string for domain or ip address
+// ScanURL scans a URL string for the URL and its domain or ip address
 func ScanURL(dest ValueWriter, input string) {
 	if input == "" {
 		return
@@ -125,6 +125,7 @@ func ScanURL(dest ValueWriter, input string) {
 	if err != nil {
 		return
 	}
+	dest.WriteValues(FieldURL, input)
 	ScanHostname(dest, u.Hostname())
 }
 
@@ -148,6 +149,44 @@ func ScanIPAddress(w ValueWriter, input string) {
 	}
 }
 
+// ScanMACAddress scans `input` for a MAC address value in the form returned by NormalizeMACAddress.
+func ScanMACAddress(w ValueWriter, input string) {
+	if mac, ok := NormalizeMACAddress(strings.TrimSpace(input)); ok {
+		w.WriteValues(FieldMACAddress, mac)
+	}
+}
+
+// NormalizeMACAddress formats a 48-bit MAC address as lowercase hex octets separated by colons
+// (i.e. `00:0b:82:01:fc:42`). Addresses without separators or with `-` and `.` separators are also accepted.
+// It is the single normalization of MAC addresses so that all parsers produce the same indicator values.
+func NormalizeMACAddress(mac string) (string, bool) {
+	const numDigits = 12
+	digits := make([]byte, 0, numDigits)
+	for i := 0; i < len(mac); i++ {
+		switch c := mac[i]; {
+		case '0' <= c && c <= '9', 'a' <= c && c <= 'f':
+			digits = append(digits, c)
+		case 'A' <= c && c <= 'F':
+			digits = append(digits, c+'a'-'A')
+		case c == ':' || c == '-' || c == '.':
+		default:
+			return "", false
+		}
+	}
+	if len(digits) != numDigits {
+		return "", false
+	}
+	var b strings.Builder
+	b.Grow(numDigits + numDigits/2 - 1)
+	for i := 0; i < numDigits; i += 2 {
+		if i > 0 {
+			b.WriteByte(':')
+		}
+		b.Write(digits[i : i+2])
+	}
+	return b.String(), true
+}
+
 // checkIPAddress checks if an IP address is valid
 // TODO: [performance] Use a simpler method to check ip addresses than net.ParseIP to avoid allocations.
 func checkIPAddress(addr string) bool {
@@ -17,3 +17,36 @@ package pantherlog
  * You should have received a copy of the GNU Affero General Public License
  * along with this program.  If not, see <https://www.gnu.org/licenses/>.
  */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanMACAddress(t *testing.T) {
+	var values ValueBuffer
+	for _, input := range []string{
+		"AA-BB-CC-DD-EE-FF",
+		" aa:bb:cc:dd:ee:ff ",
+		"aabb.ccdd.eeff",
+		"AABBCCDDEEFF",
+		// Only 48-bit addresses are accepted
+		"00:00:00:00:fe:80:00:00:00:00:00:00:02:00:5e:10:00:00:00:01",
+		"00:0c:29",
+		"not a mac",
+		"",
+	} {
+		ScanMACAddress(&values, input)
+	}
+	require.Equal(t, []string{"aa:bb:cc:dd:ee:ff"}, values.Get(FieldMACAddress))
+}
+
+func TestScanURL(t *testing.T) {
+	var values ValueBuffer
+	ScanURL(&values, "https://www.example.com/index.html?q=1")
+	ScanURL(&values, "http://10.0.0.1:8080/upload")
+	require.Equal(t, []string{"http://10.0.0.1:8080/upload", "https://www.example.com/index.html?q=1"}, values.Get(FieldURL))
+	require.Equal(t, []string{"www.example.com"}, values.Get(FieldDomainName))
+	require.Equal(t, []string{"10.0.0.1"}, values.Get(FieldIPAddress))
+}
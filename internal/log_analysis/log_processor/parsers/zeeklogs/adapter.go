@@ -0,0 +1,166 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// LineError is the error returned by an Adapter for each log line that fails to parse
+type LineError struct {
+	// LogType is empty for lines of a MultiParser that do not match any Zeek log
+	LogType string
+	// Line is the number of the line in the lines passed to the parser, starting at 1
+	Line uint64
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	if e.LogType == "" {
+		return fmt.Sprintf("failed to parse zeek log line %d: %s", e.Line, e.Err)
+	}
+	return fmt.Sprintf("failed to parse %s log line %d: %s", e.LogType, e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// AdapterConfig holds the parameters for the parsers created by the factories of Zeek log types
+type AdapterConfig struct {
+	// OnError is called for each line that fails to parse
+	OnError func(err *LineError)
+	// KeepRawLine adds the original log line to each event as the `_raw_line` field.
+	// It is off by default since it doubles the size of the stored events.
+	KeepRawLine bool
+	// Parser sets the options of the Zeek parser (e.g. `&ZeekConnParser{Strict: true}`) for the factories of the
+	// registry. A new instance of it is created with New(), it must be a parser of the same log type as the factory.
+	Parser parsers.LogParser
+}
+
+// Adapter adapts Zeek parsers to parsers.Interface and keeps track of per-line parse failures.
+// Each line that fails to parse results in a *LineError so that callers can report it and continue with the next line.
+// An Adapter parses a single stream since the parsers keep the header directives of TSV logs, so ParseLog must not be
+// called concurrently. The counters can be read from other goroutines, i.e. to report progress.
+type Adapter struct {
+	// The counters come first so that they are 64-bit aligned for atomic operations on 32-bit platforms
+	numLines    uint64
+	numErrors   uint64
+	logType     string
+	parser      parsers.Interface
+	onError     func(err *LineError)
+	keepRawLine bool
+}
+
+var _ parsers.Interface = (*Adapter)(nil)
+
+// NewAdapter creates an Adapter for a parser of `logType`.
+// The config is optional.
+func NewAdapter(logType string, parser parsers.Interface, config *AdapterConfig) *Adapter {
+	a := &Adapter{
+		logType: logType,
+		parser:  parser,
+	}
+	if config != nil {
+		a.onError = config.OnError
+		a.keepRawLine = config.KeepRawLine
+	}
+	return a
+}
+
+// ParseLog implements parsers.Interface
+func (a *Adapter) ParseLog(log string) ([]*parsers.Result, error) {
+	line := atomic.AddUint64(&a.numLines, 1)
+	results, err := a.parser.ParseLog(log)
+	observe(a.logType, results, err)
+	if err != nil {
+		return nil, a.lineError(line, err)
+	}
+	if a.keepRawLine {
+		for _, result := range results {
+			if event, ok := result.Event.(rawLineSetter); ok {
+				event.setRawLine(log)
+			}
+		}
+	}
+	return results, nil
+}
+
+// skipLine counts a line of the stream that is not passed to the parser (i.e. a line that exceeds the maximum line
+// length of a LineIterator) and reports it the same way as a line that failed to parse
+func (a *Adapter) skipLine(err error) *LineError {
+	line := atomic.AddUint64(&a.numLines, 1)
+	observe(a.logType, nil, err)
+	return a.lineError(line, err)
+}
+
+func (a *Adapter) lineError(line uint64, err error) *LineError {
+	atomic.AddUint64(&a.numErrors, 1)
+	lineErr := &LineError{
+		LogType: a.logType,
+		Line:    line,
+		Err:     err,
+	}
+	if a.onError != nil {
+		a.onError(lineErr)
+	}
+	return lineErr
+}
+
+// NumLines returns the number of lines passed to the parser, including the lines skipped by a LineIterator
+func (a *Adapter) NumLines() uint64 {
+	return atomic.LoadUint64(&a.numLines)
+}
+
+// NumErrors returns the number of lines that failed to parse
+func (a *Adapter) NumErrors() uint64 {
+	return atomic.LoadUint64(&a.numErrors)
+}
+
+// adapterFactory creates a parsers.Factory for a Zeek parser.
+// The factory accepts an optional *AdapterConfig as parameters.
+func adapterFactory(parser parsers.LogParser) parsers.Factory {
+	return parsers.FactoryFunc(func(params interface{}) (parsers.Interface, error) {
+		var config *AdapterConfig
+		switch p := params.(type) {
+		case nil:
+		case *AdapterConfig:
+			config = p
+		default:
+			return nil, errors.Errorf("invalid zeek parser params %T", params)
+		}
+		if config != nil && config.Parser != nil {
+			if logType := config.Parser.LogType(); logType != parser.LogType() {
+				return nil, errors.Errorf("invalid zeek parser for %s: %s", parser.LogType(), logType)
+			}
+			return newAdapter(config.Parser, config), nil
+		}
+		return newAdapter(parser, config), nil
+	})
+}
+
+// newAdapter creates an Adapter for a new instance of a Zeek parser
+func newAdapter(parser parsers.LogParser, config *AdapterConfig) *Adapter {
+	return NewAdapter(parser.LogType(), parsers.NewAdapter(parser), config)
+}
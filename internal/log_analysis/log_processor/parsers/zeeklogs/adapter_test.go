@@ -0,0 +1,200 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+)
+
+func TestAdapterLineErrors(t *testing.T) {
+	// nolint:lll
+	lines := []string{
+		`{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","query":"www.example.com"}`,
+		`{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16",`,
+		`{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq7","id.orig_h":"172.16.2.16","id.orig_p":43721,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","query":"www.example.org"}`,
+	}
+	var lineErrors []*LineError
+	parser, err := adapterFactory(&ZeekDNSParser{}).NewParser(&AdapterConfig{
+		OnError: func(err *LineError) {
+			lineErrors = append(lineErrors, err)
+		},
+	})
+	require.NoError(t, err)
+
+	var numEvents int
+	for i, line := range lines {
+		results, err := parser.ParseLog(line)
+		if i == 1 {
+			require.Error(t, err)
+			require.Nil(t, results)
+			lineErr := &LineError{}
+			require.True(t, errors.As(err, &lineErr))
+			require.Equal(t, uint64(2), lineErr.Line)
+			require.Equal(t, TypeZeekDNS, lineErr.LogType)
+			continue
+		}
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, TypeZeekDNS, results[0].PantherLogType)
+		numEvents++
+	}
+	require.Equal(t, 2, numEvents)
+	require.Len(t, lineErrors, 1)
+	require.Equal(t, uint64(2), lineErrors[0].Line)
+
+	adapter := parser.(*Adapter)
+	require.Equal(t, uint64(3), adapter.NumLines())
+	require.Equal(t, uint64(1), adapter.NumErrors())
+}
+
+func TestAdapterTSVArity(t *testing.T) {
+	lines := []string{
+		`#separator \x09`,
+		"#fields\tts\tuid\tid.orig_h\tid.orig_p\tid.resp_h\tid.resp_p\tproto\tquery",
+		"#types\ttime\tstring\taddr\tport\taddr\tport\tenum",
+		"1541001600.580233\tCpR9AY39cUCZ0t5qq6\t172.16.2.16\t43720\t172.16.0.2\t53\tudp\twww.example.com",
+		"1541001600.580233\tCpR9AY39cUCZ0t5qq7\t172.16.2.16\t43721\t172.16.0.2\t53\tudp",
+		"1541001600.580233\tCpR9AY39cUCZ0t5qq8\t172.16.2.16\t43722\t172.16.0.2\t53\tudp\twww.example.net",
+	}
+	parser, err := adapterFactory(&ZeekDNSParser{}).NewParser(nil)
+	require.NoError(t, err)
+
+	var lineErrors []*LineError
+	var uids []string
+	for _, line := range lines {
+		results, err := parser.ParseLog(line)
+		if err != nil {
+			lineErr := &LineError{}
+			require.True(t, errors.As(err, &lineErr))
+			lineErrors = append(lineErrors, lineErr)
+			continue
+		}
+		for _, result := range results {
+			uids = append(uids, *result.Event.(*ZeekDNS).UID)
+		}
+	}
+	// The row with a missing column is skipped instead of decoding its values to the wrong fields
+	require.Equal(t, []string{"CpR9AY39cUCZ0t5qq6", "CpR9AY39cUCZ0t5qq8"}, uids)
+	require.Len(t, lineErrors, 2)
+	require.Equal(t, uint64(3), lineErrors[0].Line)
+	require.Contains(t, lineErrors[0].Error(), "8 #fields but 7 #types")
+	require.Equal(t, uint64(5), lineErrors[1].Line)
+	require.Contains(t, lineErrors[1].Error(), "7 columns but the #fields header has 8")
+}
+
+func TestAdapterKeepRawLine(t *testing.T) {
+	// nolint:lll
+	lines := []string{
+		`{"uid":"CpR9AY39cUCZ0t5qq6", "ts":1541001600.580233,"id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","query":"www.example.com"}`,
+		"#fields\tuid\tts\tid.orig_h\tid.orig_p\tid.resp_h\tid.resp_p\tproto\tquery",
+		"CpR9AY39cUCZ0t5qq7\t1541001600.580233\t172.16.2.16\t43721\t172.16.0.2\t53\tudp\twww.example\\x2corg",
+	}
+	for _, keepRawLine := range []bool{true, false} {
+		parser, err := adapterFactory(&ZeekDNSParser{}).NewParser(&AdapterConfig{
+			KeepRawLine: keepRawLine,
+		})
+		require.NoError(t, err)
+		for i, line := range lines {
+			results, err := parser.ParseLog(line)
+			require.NoError(t, err)
+			if i == 1 {
+				require.Empty(t, results)
+				continue
+			}
+			require.Len(t, results, 1)
+			event := results[0].Event.(*ZeekDNS)
+			data, err := jsoniter.Marshal(results[0])
+			require.NoError(t, err)
+			if !keepRawLine {
+				require.Nil(t, event.RawLine)
+				require.Equal(t, jsoniter.InvalidValue, jsoniter.Get(data, "_raw_line").ValueType())
+				continue
+			}
+			require.Equal(t, line, *event.RawLine)
+			require.Equal(t, line, jsoniter.Get(data, "_raw_line").ToString())
+		}
+	}
+}
+
+func TestAdapterFactoryParams(t *testing.T) {
+	_, err := adapterFactory(&ZeekDNSParser{}).NewParser(nil)
+	require.NoError(t, err)
+	_, err = adapterFactory(&ZeekDNSParser{}).NewParser("foo")
+	require.Error(t, err)
+	_, err = adapterFactory(&ZeekDNSParser{}).NewParser(&AdapterConfig{
+		Parser: &ZeekConnParser{},
+	})
+	require.Error(t, err)
+}
+
+func TestAdapterFactoryParserOptions(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"C3zRsb2bhMLFBOaz9b","id.orig_h":"172.16.2.16","id.orig_p":52856,"id.resp_h":"52.94.233.129","id.resp_p":443,"proto":"tcp","history":"ShADadFf","extra":"foo"}`
+	entry := logtypes.DefaultRegistry().MustGet(TypeZeekConn)
+
+	parser, err := entry.NewParser(&AdapterConfig{
+		Parser: &ZeekConnParser{HistoryFlags: true},
+	})
+	require.NoError(t, err)
+	results, err := parser.ParseLog(log)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, aws.Bool(true), results[0].Event.(*ZeekConn).OrigSYN)
+
+	parser, err = entry.NewParser(&AdapterConfig{
+		Parser: &ZeekConnParser{Strict: true},
+	})
+	require.NoError(t, err)
+	_, err = parser.ParseLog(log)
+	require.Error(t, err)
+
+	// The default parser has no options
+	parser, err = entry.NewParser(nil)
+	require.NoError(t, err)
+	results, err = parser.ParseLog(log)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Nil(t, results[0].Event.(*ZeekConn).OrigSYN)
+}
+
+func TestAdapterCountersConcurrent(t *testing.T) {
+	parser, err := adapterFactory(&ZeekDNSParser{}).NewParser(nil)
+	require.NoError(t, err)
+	adapter := parser.(*Adapter)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_, _ = adapter.ParseLog(`{"ts":"invalid"}`)
+		}
+	}()
+	// Counters are read while the stream is parsed
+	for adapter.NumLines() < 100 {
+		require.LessOrEqual(t, adapter.NumErrors(), adapter.NumLines())
+	}
+	<-done
+	require.Equal(t, uint64(100), adapter.NumErrors())
+}
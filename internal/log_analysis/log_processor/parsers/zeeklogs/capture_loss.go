@@ -0,0 +1,101 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekCaptureLoss struct {
+	TS          *Time    `json:"ts,omitempty" validate:"required" description:"Timestamp for when the measurement occurred."`
+	TSDelta     *float64 `json:"ts_delta,omitempty" description:"The time delay between this measurement and the last."`
+	Peer        *string  `json:"peer,omitempty" description:"In the event that there are multiple Zeek instances logging to the same host, this distinguishes each peer with its individual name."`
+	Gaps        *uint64  `json:"gaps,omitempty" description:"Number of missed ACKs from the previous measurement interval."`
+	Acks        *uint64  `json:"acks,omitempty" description:"Total number of ACKs seen in the previous measurement interval."`
+	PercentLost *float64 `json:"percent_lost,omitempty" description:"Percentage of ACKs seen where the data being ACKed wasn’t seen."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekCaptureLossParser parses zeek capture loss logs
+type ZeekCaptureLossParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekCaptureLossParser)(nil)
+
+func (p *ZeekCaptureLossParser) New() parsers.LogParser {
+	return &ZeekCaptureLossParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekCaptureLossParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekCaptureLoss := &ZeekCaptureLoss{}
+
+	ok, err := p.decoder.Decode(log, pathCaptureLoss, zeekCaptureLoss, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekCaptureLoss.updatePantherFields(p)
+	p.Indicators.apply(&zeekCaptureLoss.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekCaptureLoss); err != nil {
+		return nil, err
+	}
+
+	return zeekCaptureLoss.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekCaptureLossParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekCaptureLossParser) LogType() string {
+	return TypeZeekCaptureLoss
+}
+
+func (event *ZeekCaptureLoss) updatePantherFields(p *ZeekCaptureLossParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+}
@@ -0,0 +1,70 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZeekCaptureLoss(t *testing.T) {
+	checkZeekFixture(t, zeekFixture{
+		LogType: TypeZeekCaptureLoss,
+		// nolint:lll
+		Log: `{"ts":1541001600.580233,"ts_delta":900.000014,"peer":"worker-1-1","gaps":41523,"acks":98210,"percent_lost":42.279808}`,
+		Expect: `{
+			"ts": "2018-10-31 16:00:00.580233000",
+			"ts_delta": 900.000014,
+			"peer": "worker-1-1",
+			"gaps": 41523,
+			"acks": 98210,
+			"percent_lost": 42.279808,
+			"p_log_type": "Zeek.Capture_Loss",
+			"p_event_time": "2018-10-31 16:00:00.580233000"
+		}`,
+		Golden: "capture_loss",
+	})
+}
+
+func TestZeekCaptureLossTSV(t *testing.T) {
+	parser := (&ZeekCaptureLossParser{}).New()
+	for _, line := range []string{
+		`#separator \x09`,
+		"#path\tcapture_loss",
+		"#fields\tts\tts_delta\tpeer\tgaps\tacks\tpercent_lost",
+		"#types\ttime\tinterval\tstring\tcount\tcount\tdouble",
+	} {
+		logs, err := parser.Parse(line)
+		require.NoError(t, err)
+		require.Nil(t, logs)
+	}
+	logs, err := parser.Parse("1541001600.580233\t900.000014\tworker-1-1\t0\t0\t0.0")
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	event := logs[0].Event().(*ZeekCaptureLoss)
+	require.Equal(t, aws.Uint64(0), event.Gaps)
+	require.Equal(t, aws.Float64(0), event.PercentLost)
+}
+
+func TestZeekCaptureLossType(t *testing.T) {
+	parser := &ZeekCaptureLossParser{}
+	require.Equal(t, "Zeek.Capture_Loss", parser.LogType())
+}
@@ -0,0 +1,149 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"crypto/sha1" // nolint:gosec
+	"encoding/base64"
+	"encoding/binary"
+	"net"
+	"strings"
+)
+
+// Community ID flow hashing
+// https://github.com/corelight/community-id-spec
+const (
+	communityIDVersion = "1:"
+	communityIDSeed    = 0
+)
+
+// IANA protocol numbers for the transport protocols logged by Zeek
+const (
+	protoICMP   = 1
+	protoTCP    = 6
+	protoUDP    = 17
+	protoICMPv6 = 58
+	protoSCTP   = 132
+)
+
+// ICMP message types that form request/response pairs and are hashed like ports of bidirectional flows
+var (
+	icmpPairs = map[uint16]uint16{
+		8:  0,  // Echo
+		0:  8,  // Echo Reply
+		13: 14, // Timestamp
+		14: 13, // Timestamp Reply
+		15: 16, // Information Request
+		16: 15, // Information Reply
+		10: 9,  // Router Solicitation
+		9:  10, // Router Advertisement
+		17: 18, // Address Mask Request
+		18: 17, // Address Mask Reply
+	}
+	icmpv6Pairs = map[uint16]uint16{
+		128: 129, // Echo Request
+		129: 128, // Echo Reply
+		133: 134, // Router Solicitation
+		134: 133, // Router Advertisement
+		135: 136, // Neighbor Solicitation
+		136: 135, // Neighbor Advertisement
+		130: 131, // Multicast Listener Query
+		131: 130, // Multicast Listener Report
+		139: 140, // Node Information Query
+		140: 139, // Node Information Response
+		144: 145, // Home Agent Address Discovery Request
+		145: 144, // Home Agent Address Discovery Reply
+	}
+)
+
+// communityID computes the Community ID v1 hash of a flow.
+// For ICMP flows Zeek logs the message type as the originator port and the message code as the responder port.
+// It returns false if the addresses are not valid IPs or the protocol does not have ports.
+func communityID(origH string, origP uint16, respH string, respP uint16, proto string) (string, bool) {
+	src, dst := net.ParseIP(origH), net.ParseIP(respH)
+	if src == nil || dst == nil {
+		return "", false
+	}
+	// Both addresses should use the same length
+	if src4, dst4 := src.To4(), dst.To4(); src4 != nil && dst4 != nil {
+		src, dst = src4, dst4
+	} else {
+		src, dst = src.To16(), dst.To16()
+	}
+	oneWay := false
+	var protoNum uint8
+	switch strings.ToLower(proto) {
+	case "tcp":
+		protoNum = protoTCP
+	case "udp":
+		protoNum = protoUDP
+	case "sctp":
+		protoNum = protoSCTP
+	case "icmp":
+		// Zeek logs both ICMP and ICMPv6 as `icmp`
+		pairs := icmpPairs
+		protoNum = protoICMP
+		if len(src) == net.IPv6len {
+			pairs = icmpv6Pairs
+			protoNum = protoICMPv6
+		}
+		if reply, ok := pairs[origP]; ok {
+			respP = reply
+		} else {
+			oneWay = true
+		}
+	default:
+		return "", false
+	}
+	if !oneWay {
+		if cmp := bytes.Compare(src, dst); cmp > 0 || (cmp == 0 && origP > respP) {
+			src, dst = dst, src
+			origP, respP = respP, origP
+		}
+	}
+
+	buf := make([]byte, 0, 2+2*net.IPv6len+2+4)
+	buf = appendUint16(buf, communityIDSeed)
+	buf = append(buf, src...)
+	buf = append(buf, dst...)
+	buf = append(buf, protoNum, 0)
+	buf = appendUint16(buf, origP)
+	buf = appendUint16(buf, respP)
+	sum := sha1.Sum(buf) // nolint:gosec
+	return communityIDVersion + base64.StdEncoding.EncodeToString(sum[:]), true
+}
+
+// communityIDPtr computes the Community ID v1 hash of a flow if all parts of the tuple are set
+func communityIDPtr(origH *string, origP *uint16, respH *string, respP *uint16, proto *string) *string {
+	if origH == nil || origP == nil || respH == nil || respP == nil || proto == nil {
+		return nil
+	}
+	id, ok := communityID(*origH, *origP, *respH, *respP, *proto)
+	if !ok {
+		return nil
+	}
+	return &id
+}
+
+func appendUint16(dst []byte, n uint16) []byte {
+	var b [2]byte
+	binary.BigEndian.PutUint16(b[:], n)
+	return append(dst, b[:]...)
+}
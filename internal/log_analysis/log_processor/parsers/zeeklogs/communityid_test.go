@@ -0,0 +1,60 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+)
+
+// Test vectors from https://github.com/corelight/community-id-spec
+func TestCommunityID(t *testing.T) {
+	type testCase struct {
+		OrigH  string
+		OrigP  uint16
+		RespH  string
+		RespP  uint16
+		Proto  string
+		Expect string
+	}
+	for _, tc := range []testCase{
+		{"128.232.110.120", 34855, "66.35.250.204", 80, "tcp", "1:LQU9qZlK+B5F3KDmev6m5PMibrg="},
+		{"66.35.250.204", 80, "128.232.110.120", 34855, "tcp", "1:LQU9qZlK+B5F3KDmev6m5PMibrg="},
+		{"192.168.1.52", 54585, "8.8.8.8", 53, "udp", "1:d/FP5EW3wiY1vCndhwleRRKHowQ="},
+		{"8.8.8.8", 53, "192.168.1.52", 54585, "udp", "1:d/FP5EW3wiY1vCndhwleRRKHowQ="},
+		{"192.168.0.89", 8, "192.168.0.1", 0, "icmp", "1:X0snYXpgwiv9TZtqg64sgzUn6Dk="},
+		{"192.168.0.1", 0, "192.168.0.89", 0, "icmp", "1:X0snYXpgwiv9TZtqg64sgzUn6Dk="},
+		{"fe80::200:86ff:fe05:80da", 135, "fe80::260:97ff:fe07:69ea", 0, "icmp", "1:dGHyGvjMfljg6Bppwm3bg0LO8TY="},
+		{"fe80::260:97ff:fe07:69ea", 136, "fe80::200:86ff:fe05:80da", 0, "icmp", "1:dGHyGvjMfljg6Bppwm3bg0LO8TY="},
+	} {
+		id, ok := communityID(tc.OrigH, tc.OrigP, tc.RespH, tc.RespP, tc.Proto)
+		require.True(t, ok, tc)
+		require.Equal(t, tc.Expect, id, tc)
+	}
+}
+
+func TestCommunityIDInvalid(t *testing.T) {
+	_, ok := communityID("not-an-ip", 34855, "66.35.250.204", 80, "tcp")
+	require.False(t, ok)
+	_, ok = communityID("128.232.110.120", 34855, "66.35.250.204", 80, "unknown_transport")
+	require.False(t, ok)
+	require.Nil(t, communityIDPtr(aws.String("128.232.110.120"), nil, aws.String("66.35.250.204"), aws.Uint16(80), aws.String("tcp")))
+}
@@ -0,0 +1,191 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekConn struct {
+	TS             *Time    `json:"ts,omitempty" validate:"required" description:"This is the time of the first packet."`
+	UID            *string  `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection."`
+	IDOrigH        *string  `json:"id.orig_h" panther:"ip" validate:"required" description:"The originator’s IP address."`
+	IDOrigP        *uint16  `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
+	IDRespH        *string  `json:"id.resp_h" panther:"ip" validate:"required" description:"The responder’s IP address."`
+	IDRespP        *uint16  `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
+	Proto          *string  `json:"proto" validate:"required" description:"The transport layer protocol of the connection."`
+	Service        *string  `json:"service,omitempty" description:"An identification of an application protocol being sent in the connection."`
+	Duration       *float64 `json:"duration,omitempty" description:"How long the connection lasted. For 3-way or 4-way connection tear-downs, this will not include the final ACK."`
+	DurationMillis *float64 `json:"duration_ms,omitempty" description:"How long the connection lasted in milliseconds, only set if the parser has Milliseconds enabled."`
+	OrigBytes      *uint64  `json:"orig_bytes,omitempty" description:"The number of payload bytes the originator sent. For TCP this is taken from sequence numbers and might be inaccurate (e.g., due to large connections)."`
+	RespBytes      *uint64  `json:"resp_bytes,omitempty" description:"The number of payload bytes the responder sent. See orig_bytes."`
+	ConnState      *string  `json:"conn_state,omitempty" description:"A short code summarizing the state of the connection (e.g. S0, SF, REJ)."`
+	LocalOrig      *bool    `json:"local_orig,omitempty" description:"If the connection is originated locally, this value will be T. If it was originated remotely it will be F."`
+	LocalResp      *bool    `json:"local_resp,omitempty" description:"If the connection is responded to locally, this value will be T. If it was responded to remotely it will be F."`
+	MissedBytes    *uint64  `json:"missed_bytes,omitempty" description:"Indicates the number of bytes missed in content gaps, which is representative of packet loss."`
+	History        *string  `json:"history,omitempty" description:"Records the state history of connections as a string of letters. Uppercase letters indicate the originator and lowercase the responder."`
+	OrigPkts       *uint64  `json:"orig_pkts,omitempty" description:"Number of packets that the originator sent."`
+	OrigIPBytes    *uint64  `json:"orig_ip_bytes,omitempty" description:"Number of IP level bytes that the originator sent (as seen on the wire, taken from the IP total_length header field)."`
+	RespPkts       *uint64  `json:"resp_pkts,omitempty" description:"Number of packets that the responder sent."`
+	RespIPBytes    *uint64  `json:"resp_ip_bytes,omitempty" description:"Number of IP level bytes that the responder sent (as seen on the wire, taken from the IP total_length header field)."`
+	TunnelParents  Set      `json:"tunnel_parents,omitempty" description:"If this connection was over a tunnel, indicate the uid values for any encapsulating parent connections used over the lifetime of this inner connection."`
+	CommunityID    *string  `json:"community_id,omitempty" description:"The Community ID hash of the connection 5-tuple (https://github.com/corelight/community-id-spec)."`
+	OrigL2Addr     *string  `json:"orig_l2_addr,omitempty" panther:"mac" description:"Link-layer address of the originator, if available (requires the mac-logging policy script)."`
+	RespL2Addr     *string  `json:"resp_l2_addr,omitempty" panther:"mac" description:"Link-layer address of the responder, if available (requires the mac-logging policy script)."`
+	OrigCC         *string  `json:"orig_cc,omitempty" description:"The two-letter country code of the originator address, if available (requires GeoIP support)."`
+	RespCC         *string  `json:"resp_cc,omitempty" description:"The two-letter country code of the responder address, if available (requires GeoIP support)."`
+	OrigSYN        *bool    `json:"orig_syn,omitempty" description:"Whether the originator sent a SYN, derived from history if the parser has HistoryFlags enabled."`
+	OrigFIN        *bool    `json:"orig_fin,omitempty" description:"Whether the originator sent a FIN, derived from history if the parser has HistoryFlags enabled."`
+	OrigRST        *bool    `json:"orig_rst,omitempty" description:"Whether the originator sent a RST, derived from history if the parser has HistoryFlags enabled."`
+	RespSYN        *bool    `json:"resp_syn,omitempty" description:"Whether the responder sent a SYN, derived from history if the parser has HistoryFlags enabled."`
+	RespFIN        *bool    `json:"resp_fin,omitempty" description:"Whether the responder sent a FIN, derived from history if the parser has HistoryFlags enabled."`
+	RespRST        *bool    `json:"resp_rst,omitempty" description:"Whether the responder sent a RST, derived from history if the parser has HistoryFlags enabled."`
+	Direction      *string  `json:"direction,omitempty" description:"Direction of the connection relative to the local networks of the parser (inbound, outbound, internal or external)."`
+	ResolvedHost   *string  `json:"resolved_host,omitempty" panther:"domain" description:"The domain name the responder address resolved to in a recent DNS answer, only set if DNS enrichment is enabled."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekConnParser parses zeek conn logs
+type ZeekConnParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	// LocalNetworks are the internal networks used to set the direction of connections, RFC 1918 ranges by default
+	LocalNetworks Networks
+	// Milliseconds adds a field with the value in milliseconds next to each duration field in seconds
+	Milliseconds bool
+	// HistoryFlags adds fields for the SYN, FIN and RST flags of each side of a connection derived from its history
+	HistoryFlags bool
+	decoder      logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekConnParser)(nil)
+
+func (p *ZeekConnParser) New() parsers.LogParser {
+	return &ZeekConnParser{
+		Strict:        p.Strict,
+		TimeOffset:    p.TimeOffset,
+		MinTime:       p.MinTime,
+		MaxTime:       p.MaxTime,
+		Indicators:    p.Indicators,
+		Milliseconds:  p.Milliseconds,
+		HistoryFlags:  p.HistoryFlags,
+		LocalNetworks: p.LocalNetworks,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekConnParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekConn := &ZeekConn{}
+
+	ok, err := p.decoder.Decode(log, pathConn, zeekConn, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekConn.setCommunityID()
+	zeekConn.normalizeL2Addrs()
+	zeekConn.Direction = connDirection(p.LocalNetworks, zeekConn.IDOrigH, zeekConn.IDRespH)
+	if p.Milliseconds {
+		zeekConn.DurationMillis = secondsToMillis(zeekConn.Duration)
+	}
+	if p.HistoryFlags {
+		zeekConn.setHistoryFlags()
+	}
+	zeekConn.updatePantherFields(p)
+	p.Indicators.apply(&zeekConn.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekConn); err != nil {
+		return nil, err
+	}
+
+	return zeekConn.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekConnParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekConnParser) LogType() string {
+	return TypeZeekConn
+}
+
+// setCommunityID computes the Community ID of the connection unless it was logged by Zeek
+func (event *ZeekConn) setCommunityID() {
+	if event.CommunityID == nil {
+		event.CommunityID = communityIDPtr(event.IDOrigH, event.IDOrigP, event.IDRespH, event.IDRespP, event.Proto)
+	}
+}
+
+// normalizeL2Addrs formats the link-layer addresses of the connection the same way regardless of the sensor
+func (event *ZeekConn) normalizeL2Addrs() {
+	for _, addr := range []*string{event.OrigL2Addr, event.RespL2Addr} {
+		if addr != nil {
+			*addr = normalizeMAC(*addr)
+		}
+	}
+}
+
+// setHistoryFlags sets the flag fields of the connection from the letters of its history.
+// Uppercase letters are packets of the originator and lowercase letters packets of the responder.
+// A SYN is either `s` (SYN without ACK) or `h` (SYN+ACK).
+func (event *ZeekConn) setHistoryFlags() {
+	if !isSetPtr(event.History) {
+		return
+	}
+	history := *event.History
+	hasFlag := func(letters string) *bool {
+		flag := strings.ContainsAny(history, letters)
+		return &flag
+	}
+	event.OrigSYN = hasFlag("SH")
+	event.OrigFIN = hasFlag("F")
+	event.OrigRST = hasFlag("R")
+	event.RespSYN = hasFlag("sh")
+	event.RespFIN = hasFlag("f")
+	event.RespRST = hasFlag("r")
+}
+
+func (event *ZeekConn) updatePantherFields(p *ZeekConnParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+
+	appendMACAddressPtr(&event.ZeekPantherLog, event.OrigL2Addr)
+	appendMACAddressPtr(&event.ZeekPantherLog, event.RespL2Addr)
+}
@@ -0,0 +1,305 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekConn(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"C3zRsb2bhMLFBOaz9b","id.orig_h":"172.16.2.16","id.orig_p":52856,"id.resp_h":"52.94.233.129","id.resp_p":443,"proto":"tcp","service":"ssl","duration":0.076351,"orig_bytes":1104,"resp_bytes":4984,"conn_state":"SF","missed_bytes":0,"history":"ShADadFf","orig_pkts":12,"orig_ip_bytes":1740,"resp_pkts":10,"resp_ip_bytes":5512}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekConn{
+		TS:          (*Time)(&expectedTime),
+		UID:         aws.String("C3zRsb2bhMLFBOaz9b"),
+		IDOrigH:     aws.String("172.16.2.16"),
+		IDOrigP:     aws.Uint16(52856),
+		IDRespH:     aws.String("52.94.233.129"),
+		IDRespP:     aws.Uint16(443),
+		Proto:       aws.String("tcp"),
+		CommunityID: aws.String("1:OTc9rg2Oj62Nv9JEeD8E+2FuXK0="),
+		Service:     aws.String("ssl"),
+		Duration:    aws.Float64(0.076351),
+		OrigBytes:   aws.Uint64(1104),
+		RespBytes:   aws.Uint64(4984),
+		ConnState:   aws.String("SF"),
+		MissedBytes: aws.Uint64(0),
+		History:     aws.String("ShADadFf"),
+		OrigPkts:    aws.Uint64(12),
+		OrigIPBytes: aws.Uint64(1740),
+		RespPkts:    aws.Uint64(10),
+		RespIPBytes: aws.Uint64(5512),
+		Direction:   aws.String("outbound"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Conn")
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDOrigH)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDRespH)
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekConn(t, log, expectedEvent)
+}
+
+func TestZeekConnIncomplete(t *testing.T) {
+	// Zeek omits duration and byte counts for connections that never completed
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CHhAvVGS1DHFjwGM9","id.orig_h":"172.16.2.16","id.orig_p":41718,"id.resp_h":"10.0.0.1","id.resp_p":22,"proto":"tcp","conn_state":"S0","missed_bytes":0,"history":"S","orig_pkts":1,"orig_ip_bytes":60,"resp_pkts":0,"resp_ip_bytes":0}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekConn{
+		TS:          (*Time)(&expectedTime),
+		UID:         aws.String("CHhAvVGS1DHFjwGM9"),
+		IDOrigH:     aws.String("172.16.2.16"),
+		IDOrigP:     aws.Uint16(41718),
+		IDRespH:     aws.String("10.0.0.1"),
+		IDRespP:     aws.Uint16(22),
+		Proto:       aws.String("tcp"),
+		CommunityID: aws.String("1:0FhUlF1NwIJuHvG4tDvGGpYrHJU="),
+		ConnState:   aws.String("S0"),
+		MissedBytes: aws.Uint64(0),
+		History:     aws.String("S"),
+		OrigPkts:    aws.Uint64(1),
+		OrigIPBytes: aws.Uint64(60),
+		RespPkts:    aws.Uint64(0),
+		RespIPBytes: aws.Uint64(0),
+		Direction:   aws.String("internal"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Conn")
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDOrigH)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDRespH)
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekConn(t, log, expectedEvent)
+}
+
+func TestZeekConnLargeNumbers(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"C3zRsb2bhMLFBOaz9b","id.orig_h":"172.16.2.16","id.orig_p":52856,"id.resp_h":"52.94.233.129","id.resp_p":443,"proto":"tcp","duration":1.2e-05,"orig_bytes":1104,"resp_bytes":5000000000,"resp_ip_bytes":18446744073709551615}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekConn{
+		TS:          (*Time)(&expectedTime),
+		UID:         aws.String("C3zRsb2bhMLFBOaz9b"),
+		IDOrigH:     aws.String("172.16.2.16"),
+		IDOrigP:     aws.Uint16(52856),
+		IDRespH:     aws.String("52.94.233.129"),
+		IDRespP:     aws.Uint16(443),
+		Proto:       aws.String("tcp"),
+		CommunityID: aws.String("1:OTc9rg2Oj62Nv9JEeD8E+2FuXK0="),
+		Duration:    aws.Float64(0.000012),
+		OrigBytes:   aws.Uint64(1104),
+		RespBytes:   aws.Uint64(5000000000),
+		RespIPBytes: aws.Uint64(18446744073709551615),
+		Direction:   aws.String("outbound"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Conn")
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDOrigH)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDRespH)
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekConn(t, log, expectedEvent)
+}
+
+func TestZeekConnOutOfRange(t *testing.T) {
+	// nolint:lll
+	const prefix = `{"ts":1541001600.580233,"uid":"C3zRsb2bhMLFBOaz9b","id.orig_h":"172.16.2.16","id.resp_h":"52.94.233.129","id.resp_p":443,"proto":"tcp",`
+	for field, log := range map[string]string{
+		"id.orig_p":  prefix + `"id.orig_p":70000}`,
+		"resp_bytes": prefix + `"id.orig_p":52856,"resp_bytes":18446744073709551616}`,
+		"orig_bytes": prefix + `"id.orig_p":52856,"orig_bytes":-1}`,
+		"orig_pkts":  prefix + `"id.orig_p":52856,"orig_pkts":1.5}`,
+	} {
+		_, err := (&ZeekConnParser{}).Parse(log)
+		require.Error(t, err, field)
+		fieldErr := &FieldError{}
+		require.True(t, errors.As(err, &fieldErr), field)
+		require.Equal(t, field, fieldErr.Field)
+		require.Contains(t, err.Error(), `"`+field+`"`)
+	}
+}
+
+func TestZeekConnL2Addrs(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CHhAvVGS1DHFjwGM9","id.orig_h":"172.16.2.16","id.orig_p":41718,"id.resp_h":"10.0.0.1","id.resp_p":22,"proto":"tcp","conn_state":"S0","orig_l2_addr":"00-0C-29-03-DF-AD","resp_l2_addr":"AABBCCDDEEFF"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekConn{
+		TS:          (*Time)(&expectedTime),
+		UID:         aws.String("CHhAvVGS1DHFjwGM9"),
+		IDOrigH:     aws.String("172.16.2.16"),
+		IDOrigP:     aws.Uint16(41718),
+		IDRespH:     aws.String("10.0.0.1"),
+		IDRespP:     aws.Uint16(22),
+		Proto:       aws.String("tcp"),
+		CommunityID: aws.String("1:0FhUlF1NwIJuHvG4tDvGGpYrHJU="),
+		ConnState:   aws.String("S0"),
+		OrigL2Addr:  aws.String("00:0c:29:03:df:ad"),
+		RespL2Addr:  aws.String("aa:bb:cc:dd:ee:ff"),
+		Direction:   aws.String("internal"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Conn")
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDOrigH)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDRespH)
+	expectedEvent.AppendAnyMACAddresses("00:0c:29:03:df:ad", "aa:bb:cc:dd:ee:ff")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekConn(t, log, expectedEvent)
+}
+
+func TestZeekConnMappedIPv6(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CHhAvVGS1DHFjwGM9","id.orig_h":"::ffff:172.16.2.16","id.orig_p":41718,"id.resp_h":"2001:0db8:0000:0000:0000:0000:0000:0001","id.resp_p":22,"proto":"tcp","conn_state":"S0"}`
+	logs, err := (&ZeekConnParser{}).Parse(log)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	event := logs[0].Event().(*ZeekConn)
+	require.Equal(t, "::ffff:172.16.2.16", *event.IDOrigH)
+	require.Equal(t, "2001:0db8:0000:0000:0000:0000:0000:0001", *event.IDRespH)
+
+	expect := &ZeekPantherLog{}
+	expect.AppendAnyIPAddress("172.16.2.16")
+	expect.AppendAnyIPAddress("2001:db8::1")
+	require.Equal(t, expect.PantherAnyIPAddresses, logs[0].PantherAnyIPAddresses)
+}
+
+func TestZeekConnCountryCodes(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"C3zRsb2bhMLFBOaz9b","id.orig_h":"172.16.2.16","id.orig_p":52856,"id.resp_h":"52.94.233.129","id.resp_p":443,"proto":"tcp","orig_cc":"US"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekConn{
+		TS:          (*Time)(&expectedTime),
+		UID:         aws.String("C3zRsb2bhMLFBOaz9b"),
+		IDOrigH:     aws.String("172.16.2.16"),
+		IDOrigP:     aws.Uint16(52856),
+		IDRespH:     aws.String("52.94.233.129"),
+		IDRespP:     aws.Uint16(443),
+		Proto:       aws.String("tcp"),
+		CommunityID: aws.String("1:OTc9rg2Oj62Nv9JEeD8E+2FuXK0="),
+		OrigCC:      aws.String("US"),
+		Direction:   aws.String("outbound"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Conn")
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDOrigH)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDRespH)
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekConn(t, log, expectedEvent)
+
+	// Values that are not country codes are kept as-is
+	// nolint:lll
+	log = `{"ts":1541001600.580233,"uid":"C3zRsb2bhMLFBOaz9b","id.orig_h":"172.16.2.16","id.orig_p":52856,"id.resp_h":"52.94.233.129","id.resp_p":443,"proto":"tcp","orig_cc":"","resp_cc":"USA"}`
+	logs, err := (&ZeekConnParser{}).Parse(log)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	event := logs[0].Event().(*ZeekConn)
+	require.Equal(t, aws.String(""), event.OrigCC)
+	require.Equal(t, aws.String("USA"), event.RespCC)
+}
+
+func TestZeekConnMilliseconds(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CsfuXh4mZqXJskZzwa","id.orig_h":"172.16.2.16","id.orig_p":35168,"id.resp_h":"52.94.233.129","id.resp_p":443,"proto":"tcp","duration":3.247501}`
+
+	logs, err := (&ZeekConnParser{Milliseconds: true}).New().Parse(log)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	event := logs[0].Event().(*ZeekConn)
+	require.Equal(t, aws.Float64(3.247501), event.Duration)
+	require.Equal(t, aws.Float64(3247.501), event.DurationMillis)
+}
+
+func TestZeekConnHistoryFlags(t *testing.T) {
+	// nolint:lll
+	const logFormat = `{"ts":1541001600.580233,"uid":"C3zRsb2bhMLFBOaz9b","id.orig_h":"172.16.2.16","id.orig_p":52856,"id.resp_h":"52.94.233.129","id.resp_p":443,"proto":"tcp","history":%q}`
+	type flags struct {
+		OrigSYN, OrigFIN, OrigRST, RespSYN, RespFIN, RespRST *bool
+	}
+	for _, tc := range []struct {
+		History string
+		Expect  flags
+	}{
+		// Normal handshake and tear-down
+		{"ShADadFf", flags{aws.Bool(true), aws.Bool(true), aws.Bool(false), aws.Bool(true), aws.Bool(true), aws.Bool(false)}},
+		// The responder rejects the connection and the originator keeps retrying
+		{"ShADadRrRr", flags{aws.Bool(true), aws.Bool(false), aws.Bool(true), aws.Bool(true), aws.Bool(false), aws.Bool(true)}},
+		// Connection attempt without reply
+		{"S", flags{aws.Bool(true), aws.Bool(false), aws.Bool(false), aws.Bool(false), aws.Bool(false), aws.Bool(false)}},
+		{"-", flags{}},
+	} {
+		logs, err := (&ZeekConnParser{HistoryFlags: true}).New().Parse(fmt.Sprintf(logFormat, tc.History))
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		event := logs[0].Event().(*ZeekConn)
+		require.Equal(t, tc.History, *event.History)
+		require.Equal(t, tc.Expect, flags{event.OrigSYN, event.OrigFIN, event.OrigRST, event.RespSYN, event.RespFIN, event.RespRST}, tc.History)
+	}
+
+	// Flags are off by default
+	logs, err := (&ZeekConnParser{}).Parse(fmt.Sprintf(logFormat, "ShADadFf"))
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	require.Nil(t, logs[0].Event().(*ZeekConn).OrigSYN)
+}
+
+func TestZeekConnTimeRange(t *testing.T) {
+	// nolint:lll
+	const logFormat = `{"ts":%s,"uid":"C3zRsb2bhMLFBOaz9b","id.orig_h":"172.16.2.16","id.orig_p":52856,"id.resp_h":"52.94.233.129","id.resp_p":443,"proto":"tcp"}`
+	parser := (&ZeekConnParser{
+		MinTime: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+	}).New()
+
+	logs, err := parser.Parse(fmt.Sprintf(logFormat, "0.000000"))
+	require.Error(t, err)
+	require.Nil(t, logs)
+	rangeErr := &TimeRangeError{}
+	require.True(t, errors.As(err, &rangeErr))
+	require.Equal(t, time.Unix(0, 0).UTC(), rangeErr.Time)
+
+	logs, err = parser.Parse(fmt.Sprintf(logFormat, "1541001600.580233"))
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+}
+
+func TestZeekConnType(t *testing.T) {
+	parser := &ZeekConnParser{}
+	require.Equal(t, "Zeek.Conn", parser.LogType())
+}
+
+func checkZeekConn(t *testing.T, log string, expectedEvent *ZeekConn) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekConnParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
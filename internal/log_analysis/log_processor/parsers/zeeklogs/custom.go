@@ -0,0 +1,365 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// CustomZeekTypeOptions holds the options of the parser of a custom Zeek log type.
+// These are the same options as the parsers of the built-in Zeek log types.
+type CustomZeekTypeOptions struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	// DetectFields identify the lines of the log in a MultiParser if they have no `_path` field.
+	// Lines are only detected by their `_path` if it is empty.
+	DetectFields []string
+}
+
+// RegisterCustomZeekType registers a log type for the logs of a custom Zeek policy script.
+//
+// The name of the log type must start with `Zeek.` and the rest of the name in lowercase is the Zeek log path expected
+// in the `#path` directive of TSV logs and the `_path` field of JSON logs (e.g. `app_metrics` for `Zeek.App_Metrics`).
+// The schema must be a pointer to a struct that embeds ZeekPantherLog and should also embed ZeekMeta.
+// A `ts` field of type *Time is used as the event time.
+// The values of string fields with a `panther` struct tag (ip, domain, hostname, url, username, email, mac, md5, sha1
+// or sha256) are added to the indicator fields of each event the same way as for the built-in Zeek log types.
+//
+// If the config has no parser factory, logs are parsed with the same JSON and TSV decoding as the built-in types
+// and the log type is also parsed by the MultiParsers created after registering it. The options are optional.
+// It fails if a log type with the same name or Zeek log path is already registered.
+func RegisterCustomZeekType(config logtypes.Config, options *CustomZeekTypeOptions) error {
+	schema, err := newCustomSchema(config.Name, config.Schema)
+	if err != nil {
+		return err
+	}
+	if options == nil {
+		options = &CustomZeekTypeOptions{}
+	}
+	customMultiLogs.Lock()
+	defer customMultiLogs.Unlock()
+	if _, duplicate := customMultiLogs.paths[schema.path]; duplicate || hasMultiLogPath(schema.path, multiLogs) {
+		return errors.Errorf("duplicate zeek log path %q", schema.path)
+	}
+	var parser *customParser
+	if config.NewParser == nil {
+		parser = &customParser{
+			CustomZeekTypeOptions: *options,
+			logType:               config.Name,
+			schema:                schema,
+		}
+		config.NewParser = adapterFactory(parser)
+	}
+	if _, err := logtypes.DefaultRegistry().Register(config); err != nil {
+		return err
+	}
+	// The paths of all custom log types are recorded, including those with their own parser factory
+	if customMultiLogs.paths == nil {
+		customMultiLogs.paths = map[string]string{}
+	}
+	customMultiLogs.paths[schema.path] = config.Name
+	if parser != nil {
+		customMultiLogs.logs = append(customMultiLogs.logs, multiLog{
+			Path:   schema.path,
+			Parser: parser,
+			Fields: options.DetectFields,
+		})
+	}
+	return nil
+}
+
+// customMultiLogs are the custom Zeek log types handled by MultiParser
+var customMultiLogs struct {
+	sync.RWMutex
+	logs []multiLog
+	// paths maps the Zeek log paths of all custom log types to their names
+	paths map[string]string
+}
+
+func hasMultiLogPath(path string, logs []multiLog) bool {
+	for _, log := range logs {
+		if log.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// unregisterCustomZeekType removes a custom log type, it is only used by tests
+func unregisterCustomZeekType(logType string) {
+	customMultiLogs.Lock()
+	defer customMultiLogs.Unlock()
+	logs := customMultiLogs.logs[:0]
+	for _, log := range customMultiLogs.logs {
+		if log.Parser.LogType() != logType {
+			logs = append(logs, log)
+		}
+	}
+	customMultiLogs.logs = logs
+	for path, name := range customMultiLogs.paths {
+		if name == logType {
+			delete(customMultiLogs.paths, path)
+		}
+	}
+	logtypes.DefaultRegistry().Del(logType)
+}
+
+// LogTypes returns the names of all registered Zeek log types, including custom types, in sorted order
+func LogTypes() []string {
+	var logTypes []string
+	for _, logType := range logtypes.DefaultRegistry().LogTypes() {
+		if strings.HasPrefix(logType, logTypePrefix) {
+			logTypes = append(logTypes, logType)
+		}
+	}
+	sort.Strings(logTypes)
+	return logTypes
+}
+
+// customSchema describes the event struct of a custom log type
+type customSchema struct {
+	path string
+	typ  reflect.Type
+	// pantherLog is the index of the embedded ZeekPantherLog
+	pantherLog []int
+	// ts is the index of the `ts` field, nil if the struct has none
+	ts         []int
+	indicators []customIndicator
+}
+
+// customIndicator is a string field with a `panther` struct tag
+type customIndicator struct {
+	name    string
+	index   []int
+	scanner string
+}
+
+func newCustomSchema(logType string, schema interface{}) (*customSchema, error) {
+	path := strings.ToLower(strings.TrimPrefix(logType, logTypePrefix))
+	if !strings.HasPrefix(logType, logTypePrefix) || path == "" {
+		return nil, errors.Errorf("%q is not a zeek log type", logType)
+	}
+	typ := reflect.TypeOf(schema)
+	if typ == nil || typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+		return nil, errors.Errorf("schema of zeek log type %q is not a pointer to a struct", logType)
+	}
+	s := &customSchema{
+		path: path,
+		typ:  typ.Elem(),
+	}
+	if err := s.collectFields(s.typ, nil); err != nil {
+		return nil, errors.Wrapf(err, "invalid schema for zeek log type %q", logType)
+	}
+	if s.pantherLog == nil {
+		return nil, errors.Errorf("schema of zeek log type %q does not embed zeeklogs.ZeekPantherLog", logType)
+	}
+	return s, nil
+}
+
+func (s *customSchema) collectFields(typ reflect.Type, index []int) error {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		fieldIndex := append(append([]int(nil), index...), i)
+		if field.Anonymous {
+			switch {
+			case field.Type == typPantherLog:
+				s.pantherLog = fieldIndex
+			case field.Type.Kind() == reflect.Struct:
+				if err := s.collectFields(field.Type, fieldIndex); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "ts" && field.Type == reflect.PtrTo(typTime) {
+			s.ts = fieldIndex
+		}
+		scanner := strings.Split(field.Tag.Get(pantherlog.TagName), ",")[0]
+		if scanner == "" {
+			continue
+		}
+		if !isCustomIndicator(scanner) {
+			return errors.Errorf("unsupported indicator %q for field %q", scanner, name)
+		}
+		switch derefType(field.Type).Kind() {
+		case reflect.String:
+		case reflect.Slice:
+			if derefType(field.Type.Elem()).Kind() == reflect.String {
+				break
+			}
+			fallthrough
+		default:
+			return errors.Errorf("indicator field %q is not a string", name)
+		}
+		s.indicators = append(s.indicators, customIndicator{
+			name:    name,
+			index:   fieldIndex,
+			scanner: scanner,
+		})
+	}
+	return nil
+}
+
+func isCustomIndicator(scanner string) bool {
+	switch scanner {
+	case "ip", "domain", "hostname", "url", "username", "email", "mac", "md5", "sha1", "sha256":
+		return true
+	default:
+		return false
+	}
+}
+
+// appendIndicators adds the values of the indicator fields of an event to its panther fields
+func (s *customSchema) appendIndicators(pl *ZeekPantherLog, indicators *IndicatorPolicy, event reflect.Value) {
+	for i := range s.indicators {
+		field := &s.indicators[i]
+		for _, value := range stringValues(event.FieldByIndex(field.index)) {
+			field.append(pl, indicators, value)
+		}
+	}
+}
+
+// stringValues returns pointers to the values of a string, *string or string slice field so that they can be
+// normalized in place
+func stringValues(v reflect.Value) []*string {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		return stringValues(v.Elem())
+	case reflect.String:
+		return []*string{v.Addr().Interface().(*string)}
+	case reflect.Slice:
+		values := make([]*string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, stringValues(v.Index(i))...)
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+func (field *customIndicator) append(pl *ZeekPantherLog, indicators *IndicatorPolicy, value *string) {
+	if !isSetPtr(value) {
+		return
+	}
+	switch field.scanner {
+	case "ip":
+		appendIPAddressField(pl, indicators, field.name, value)
+	case "domain":
+		appendDomainName(pl, *value)
+	case "hostname":
+		appendHostname(pl, *value)
+	case "url":
+		appendURL(pl, *value)
+	case "username":
+		pl.AppendAnyUsernames(*value)
+	case "email":
+		appendEmails(pl, *value)
+	case "mac":
+		appendMACAddress(pl, *value)
+	case "md5":
+		pl.AppendAnyMD5Hashes(*value)
+	case "sha1":
+		pl.AppendAnySHA1Hashes(*value)
+	case "sha256":
+		pl.AppendAnySHA256Hashes(*value)
+	}
+}
+
+// customParser parses the logs of a custom Zeek log type
+type customParser struct {
+	CustomZeekTypeOptions
+	logType string
+	schema  *customSchema
+	decoder logDecoder
+}
+
+var _ parsers.LogParser = (*customParser)(nil)
+
+func (p *customParser) New() parsers.LogParser {
+	return &customParser{
+		CustomZeekTypeOptions: p.CustomZeekTypeOptions,
+		logType:               p.logType,
+		schema:                p.schema,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *customParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	event := reflect.New(p.schema.typ)
+
+	ok, err := p.decoder.Decode(log, p.schema.path, event.Interface(), p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	pl := event.Elem().FieldByIndex(p.schema.pantherLog).Addr().Interface().(*ZeekPantherLog)
+	var ts *Time
+	if p.schema.ts != nil {
+		ts = event.Elem().FieldByIndex(p.schema.ts).Interface().(*Time)
+	}
+	pl.SetCoreFields(p.logType, (*timestamp.RFC3339)(ts), event.Interface())
+	p.schema.appendIndicators(pl, &p.Indicators, event.Elem())
+	p.Indicators.apply(pl)
+
+	if err := parsers.Validator.Struct(event.Interface()); err != nil {
+		return nil, err
+	}
+
+	return pl.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *customParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *customParser) LogType() string {
+	return p.logType
+}
@@ -0,0 +1,242 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type testAppMetrics struct {
+	TS       *Time   `json:"ts,omitempty" validate:"required" description:"The time of the measurement."`
+	Host     *string `json:"host,omitempty" panther:"ip" validate:"required" description:"The address of the application server."`
+	App      *string `json:"app,omitempty" description:"The name of the application."`
+	Requests *uint64 `json:"requests,omitempty" description:"The number of requests served."`
+	Healthy  *bool   `json:"healthy,omitempty" description:"Whether the health check of the application passed."`
+	Domains  Set     `json:"domains,omitempty" panther:"domain" description:"The domain names served by the application."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+const typeTestAppMetrics = "Zeek.App_Metrics"
+
+func registerTestAppMetrics(t *testing.T, options *CustomZeekTypeOptions) {
+	err := RegisterCustomZeekType(logtypes.Config{
+		Name:         typeTestAppMetrics,
+		Description:  `Application metrics of a custom policy script`,
+		ReferenceURL: `-`,
+		Schema:       &testAppMetrics{},
+	}, options)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		unregisterCustomZeekType(typeTestAppMetrics)
+	})
+}
+
+func TestRegisterCustomZeekType(t *testing.T) {
+	registerTestAppMetrics(t, nil)
+	require.Contains(t, LogTypes(), typeTestAppMetrics)
+	require.Contains(t, LogTypes(), TypeZeekConn)
+
+	parser, err := logtypes.DefaultRegistry().MustGet(typeTestAppMetrics).NewParser(nil)
+	require.NoError(t, err)
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &testAppMetrics{
+		TS:       (*Time)(&expectedTime),
+		Host:     aws.String("10.0.0.1"),
+		App:      aws.String("api"),
+		Requests: aws.Uint64(42),
+		Healthy:  aws.Bool(true),
+		Domains:  Set{"Example.com.", "api.example.com"},
+	}
+	expectedEvent.PantherLogType = aws.String(typeTestAppMetrics)
+	expectedEvent.AppendAnyIPAddress("10.0.0.1")
+	expectedEvent.AppendAnyDomainNames("example.com", "api.example.com")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"host":"::ffff:10.0.0.1","app":"api","requests":42,"healthy":true,"domains":["Example.com.","api.example.com"]}`
+	results, err := parser.ParseLog(log)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	// The indicator is canonical but the field keeps the logged value
+	expectedEvent.Host = aws.String("::ffff:10.0.0.1")
+	checkTestAppMetrics(t, expectedEvent, results[0].Event.(*testAppMetrics))
+	expectedEvent.Host = aws.String("10.0.0.1")
+
+	for _, line := range []string{
+		`#separator \x09`,
+		"#path\tapp_metrics",
+		"#fields\tts\thost\tapp\trequests\thealthy\tdomains",
+		"#types\ttime\taddr\tstring\tcount\tbool\tset[string]",
+	} {
+		results, err := parser.ParseLog(line)
+		require.NoError(t, err)
+		require.Empty(t, results)
+	}
+	results, err = parser.ParseLog("1541001600.580233\t10.0.0.1\tapi\t42\tT\tExample.com.,api.example.com")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	checkTestAppMetrics(t, expectedEvent, results[0].Event.(*testAppMetrics))
+
+	_, err = parser.ParseLog(`#path` + "\tconn")
+	require.Error(t, err)
+}
+
+func checkTestAppMetrics(t *testing.T, expect, actual *testAppMetrics) {
+	t.Helper()
+	require.Equal(t, expect.PantherLogType, actual.PantherLogType)
+	require.Equal(t, expect.PantherEventTime, actual.PantherEventTime)
+	require.Equal(t, expect.PantherAnyIPAddresses, actual.PantherAnyIPAddresses)
+	require.Equal(t, expect.PantherAnyDomainNames, actual.PantherAnyDomainNames)
+	actual.ZeekPantherLog = ZeekPantherLog{}
+	expect = &testAppMetrics{
+		TS:       expect.TS,
+		Host:     expect.Host,
+		App:      expect.App,
+		Requests: expect.Requests,
+		Healthy:  expect.Healthy,
+		Domains:  expect.Domains,
+	}
+	require.Equal(t, expect, actual)
+}
+
+func TestRegisterCustomZeekTypeDuplicate(t *testing.T) {
+	registerTestAppMetrics(t, nil)
+	err := RegisterCustomZeekType(logtypes.Config{
+		Name:         typeTestAppMetrics,
+		Description:  `Application metrics of a custom policy script`,
+		ReferenceURL: `-`,
+		Schema:       &testAppMetrics{},
+	}, nil)
+	require.Error(t, err)
+
+	err = RegisterCustomZeekType(logtypes.Config{
+		Name:         TypeZeekConn,
+		Description:  `Zeek IP, TCP, UDP and ICMP connection activity`,
+		ReferenceURL: `-`,
+		Schema:       &testAppMetrics{},
+	}, nil)
+	require.Error(t, err)
+}
+
+func TestRegisterCustomZeekTypeDuplicateWithParser(t *testing.T) {
+	// The path of a log type with its own parser factory is not parsed by MultiParser but it is still taken
+	err := RegisterCustomZeekType(logtypes.Config{
+		Name:         typeTestAppMetrics,
+		Description:  `Application metrics of a custom policy script`,
+		ReferenceURL: `-`,
+		Schema:       &testAppMetrics{},
+		NewParser:    adapterFactory(&ZeekConnParser{}),
+	}, nil)
+	require.NoError(t, err)
+	defer unregisterCustomZeekType(typeTestAppMetrics)
+
+	err = RegisterCustomZeekType(logtypes.Config{
+		Name:         "Zeek.APP_METRICS",
+		Description:  `Application metrics of a custom policy script`,
+		ReferenceURL: `-`,
+		Schema:       &testAppMetrics{},
+	}, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `duplicate zeek log path "app_metrics"`)
+}
+
+func TestRegisterCustomZeekTypeInvalid(t *testing.T) {
+	type noPantherLog struct {
+		TS *Time `json:"ts" description:"The time of the measurement."`
+	}
+	// nolint:lll
+	type badIndicator struct {
+		Requests *uint64 `json:"requests" panther:"ip" description:"The number of requests served."`
+		ZeekPantherLog
+	}
+	for name, config := range map[string]logtypes.Config{
+		"name":         {Name: "App_Metrics", Schema: &testAppMetrics{}},
+		"schema":       {Name: "Zeek.Invalid", Schema: testAppMetrics{}},
+		"panther log":  {Name: "Zeek.Invalid", Schema: &noPantherLog{}},
+		"indicator":    {Name: "Zeek.Invalid", Schema: &badIndicator{}},
+		"missing info": {Name: "Zeek.Invalid", Schema: &testAppMetrics{}},
+	} {
+		require.Error(t, RegisterCustomZeekType(config, nil), name)
+	}
+	require.Nil(t, logtypes.DefaultRegistry().Get("Zeek.Invalid"))
+}
+
+func TestCustomZeekTypeOptions(t *testing.T) {
+	minTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	registerTestAppMetrics(t, &CustomZeekTypeOptions{
+		Strict:  true,
+		MinTime: minTime,
+		Indicators: IndicatorPolicy{
+			Disabled: pantherlog.FieldSet{pantherlog.FieldDomainName},
+		},
+	})
+	parser, err := logtypes.DefaultRegistry().MustGet(typeTestAppMetrics).NewParser(nil)
+	require.NoError(t, err)
+
+	_, err = parser.ParseLog(`{"ts":1548979200.0,"host":"10.0.0.1","app":"api","extra":"x"}`)
+	require.Error(t, err)
+
+	_, err = parser.ParseLog(`{"ts":1541001600.580233,"host":"10.0.0.1","app":"api"}`)
+	rangeErr := &TimeRangeError{}
+	require.True(t, errors.As(err, &rangeErr))
+	require.Equal(t, minTime, rangeErr.MinTime)
+
+	results, err := parser.ParseLog(`{"ts":1548979200.0,"host":"10.0.0.1","app":"api","domains":["example.com"]}`)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	event := results[0].Event.(*testAppMetrics)
+	require.Empty(t, event.PantherAnyDomainNames)
+	require.NotEmpty(t, event.PantherAnyIPAddresses)
+}
+
+func TestMultiParserCustomType(t *testing.T) {
+	registerTestAppMetrics(t, &CustomZeekTypeOptions{
+		DetectFields: []string{"app", "requests", "healthy"},
+	})
+	parser := NewMultiParser(nil)
+	for _, log := range []string{
+		`{"_path":"app_metrics","ts":1541001600.580233,"host":"10.0.0.1","app":"api"}`,
+		`{"ts":1541001600.580233,"host":"10.0.0.1","app":"api","requests":42,"healthy":true}`,
+	} {
+		results, err := parser.ParseLog(log)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, typeTestAppMetrics, results[0].PantherLogType)
+	}
+
+	err := RegisterCustomZeekType(logtypes.Config{
+		Name:         "Zeek.APP_METRICS",
+		Description:  `Application metrics of a custom policy script`,
+		ReferenceURL: `-`,
+		Schema:       &testAppMetrics{},
+	}, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,92 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekDCERPC(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CsNHVHa1lzFtvJzT8","id.orig_h":"172.16.133.6","id.orig_p":1728,"id.resp_h":"172.16.128.202","id.resp_p":445,"rtt":0.001079,"named_pipe":"\\PIPE\\svcctl","endpoint":"svcctl","operation":"CreateServiceW"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekDCERPC{
+		TS:        (*Time)(&expectedTime),
+		UID:       aws.String("CsNHVHa1lzFtvJzT8"),
+		IDOrigH:   aws.String("172.16.133.6"),
+		IDOrigP:   aws.Uint16(1728),
+		IDRespH:   aws.String("172.16.128.202"),
+		IDRespP:   aws.Uint16(445),
+		RTT:       aws.Float64(0.001079),
+		NamedPipe: aws.String(`\PIPE\svcctl`),
+		Endpoint:  aws.String("svcctl"),
+		Operation: aws.String("CreateServiceW"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.DCE_RPC")
+	expectedEvent.AppendAnyIPAddress("172.16.133.6")
+	expectedEvent.AppendAnyIPAddress("172.16.128.202")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekDCERPC(t, log, expectedEvent)
+}
+
+func TestZeekDCERPCWithoutRTT(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CsNHVHa1lzFtvJzT8","id.orig_h":"172.16.133.6","id.orig_p":1728,"id.resp_h":"172.16.128.202","id.resp_p":135,"endpoint":"epmapper","operation":"ept_map"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekDCERPC{
+		TS:        (*Time)(&expectedTime),
+		UID:       aws.String("CsNHVHa1lzFtvJzT8"),
+		IDOrigH:   aws.String("172.16.133.6"),
+		IDOrigP:   aws.Uint16(1728),
+		IDRespH:   aws.String("172.16.128.202"),
+		IDRespP:   aws.Uint16(135),
+		Endpoint:  aws.String("epmapper"),
+		Operation: aws.String("ept_map"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.DCE_RPC")
+	expectedEvent.AppendAnyIPAddress("172.16.133.6")
+	expectedEvent.AppendAnyIPAddress("172.16.128.202")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekDCERPC(t, log, expectedEvent)
+}
+
+func TestZeekDCERPCType(t *testing.T) {
+	parser := &ZeekDCERPCParser{}
+	require.Equal(t, "Zeek.DCE_RPC", parser.LogType())
+}
+
+func checkZeekDCERPC(t *testing.T, log string, expectedEvent *ZeekDCERPC) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekDCERPCParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
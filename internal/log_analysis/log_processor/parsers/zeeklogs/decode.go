@@ -0,0 +1,403 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+	"github.com/pkg/errors"
+)
+
+// logDecoder decodes Zeek log lines written either as JSON objects or as TSV rows.
+// Decoding TSV rows depends on the header directives that precede them so a logDecoder is stateful and should not be
+// shared between parser instances. The zero value is ready to use.
+type logDecoder struct {
+	tsv tsvReader
+	// fields holds the JSON field names of the event type, it is only used in strict mode
+	fields map[string]reflect.Type
+	// ts is the index of the `ts` field of the event type, it is only used if the time range is bounded
+	ts []int
+}
+
+// Decode decodes a log line of the Zeek log `path` into `event`.
+// It returns false if the line was a TSV header directive and did not contain any event.
+// In strict mode it fails if the log has fields that are not part of the event schema.
+// A non-zero `offset` is added to all timestamps of the event.
+// Events with a `ts` outside the time range (after adding the offset) fail with a *TimeRangeError.
+// If a JSON log has duplicate keys the last value is used.
+func (d *logDecoder) Decode(log, path string, event interface{}, strict bool, offset time.Duration, bounds timeRange) (bool, error) {
+	if strings.HasPrefix(log, "#") {
+		return false, d.tsv.ReadDirective(log, path)
+	}
+	var data []byte
+	if d.tsv.HasFields() && !strings.HasPrefix(log, "{") {
+		row, err := d.tsv.ReadRow(log, event)
+		if err != nil {
+			return true, err
+		}
+		data = row
+	} else {
+		buf := borrowBuffer()
+		defer returnBuffer(buf)
+		data = append((*buf)[:0], log...)
+		*buf = data
+	}
+	if err := unmarshalEvent(data, event); err != nil {
+		return true, err
+	}
+	shiftTimes(event, offset)
+	if err := d.checkTimeRange(event, bounds); err != nil {
+		return true, err
+	}
+	if strict {
+		return true, d.checkFields(data, path, event)
+	}
+	return true, nil
+}
+
+// FieldError is the error returned when a field of a Zeek log has a value that does not match its type,
+// i.e. a number that is out of range for an integer field.
+type FieldError struct {
+	// Field is the name of the field in the Zeek log
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("invalid value for zeek field %q: %s", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// unmarshalEvent decodes a JSON object to an event.
+// If the value of a field fails to decode the error is a *FieldError.
+func unmarshalEvent(data []byte, event interface{}) error {
+	iter := zeekJSON.BorrowIterator(data)
+	defer zeekJSON.ReturnIterator(iter)
+	iter.ReadVal(event)
+	if err, ok := iter.Attachment.(*FieldError); ok {
+		return err
+	}
+	if iter.Error != nil {
+		return iter.Error
+	}
+	if iter.WhatIsNext() != jsoniter.InvalidValue {
+		return errors.New("unexpected data after zeek JSON log")
+	}
+	return nil
+}
+
+// zeekJSON decodes the JSON objects of all Zeek logs.
+// Some Zeek plugins write a single value instead of a one-element array for `vector` fields, so a scalar JSON value is
+// decoded as a one-element slice for all slice fields.
+// Fields also decode from the names listed in their `aliases` struct tag.
+// Booleans also decode from the string forms written by Zeek and log converters (see parseBool).
+var zeekJSON = newZeekJSON()
+
+func newZeekJSON() jsoniter.API {
+	api := jsoniter.Config{
+		EscapeHTML: true,
+	}.Froze()
+	api.RegisterExtension(&scalarSliceExtension{})
+	api.RegisterExtension(&fieldAliasExtension{})
+	api.RegisterExtension(&boolExtension{})
+	api.RegisterExtension(&fieldErrorExtension{})
+	return api
+}
+
+var (
+	typJSONUnmarshaler = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	typBytes           = reflect.TypeOf([]byte(nil))
+	typBool            = reflect.TypeOf(false)
+	typBoolPtr         = reflect.PtrTo(typBool)
+)
+
+type scalarSliceExtension struct {
+	jsoniter.DummyExtension
+}
+
+func (*scalarSliceExtension) DecorateDecoder(typ reflect2.Type, dec jsoniter.ValDecoder) jsoniter.ValDecoder {
+	if typ.Kind() != reflect.Slice || typ.Type1() == typBytes {
+		return dec
+	}
+	// Types with a custom decoder (e.g. Set) handle scalar values on their own
+	if reflect.PtrTo(typ.Type1()).Implements(typJSONUnmarshaler) {
+		return dec
+	}
+	return &scalarSliceDecoder{
+		decoder: dec,
+	}
+}
+
+// scalarSliceDecoder decodes scalar JSON values as one-element arrays
+type scalarSliceDecoder struct {
+	decoder jsoniter.ValDecoder
+}
+
+func (d *scalarSliceDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	switch iter.WhatIsNext() {
+	case jsoniter.ArrayValue, jsoniter.NilValue:
+		d.decoder.Decode(ptr, iter)
+		return
+	}
+	value := iter.SkipAndReturnBytes()
+	if iter.Error != nil {
+		return
+	}
+	data := make([]byte, 0, len(value)+2)
+	data = append(data, '[')
+	data = append(data, value...)
+	data = append(data, ']')
+	elem := iter.Pool().BorrowIterator(data)
+	defer iter.Pool().ReturnIterator(elem)
+	d.decoder.Decode(ptr, elem)
+	if err := elem.Error; err != nil {
+		iter.ReportError("decode zeek vector", err.Error())
+	}
+}
+
+// boolExtension decodes all boolean fields with readBool so that the value of a flag does not depend on the format
+// of the log source
+type boolExtension struct {
+	jsoniter.DummyExtension
+}
+
+func (*boolExtension) CreateDecoder(typ reflect2.Type) jsoniter.ValDecoder {
+	switch typ.Type1() {
+	case typBool:
+		return &boolDecoder{}
+	case typBoolPtr:
+		return &boolPtrDecoder{}
+	default:
+		return nil
+	}
+}
+
+// boolDecoder decodes a bool, unset values leave it unchanged
+type boolDecoder struct{}
+
+func (*boolDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	if value, ok := readBool(iter); ok {
+		*(*bool)(ptr) = value
+	}
+}
+
+// boolPtrDecoder decodes a *bool, unset values decode as nil
+type boolPtrDecoder struct{}
+
+func (*boolPtrDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	value, ok := readBool(iter)
+	if !ok {
+		*(**bool)(ptr) = nil
+		return
+	}
+	*(**bool)(ptr) = &value
+}
+
+// readBool reads a JSON boolean, null, or a string or number accepted by parseBool.
+// It returns false if the value is unset or invalid, in which case an error is reported to the iterator.
+func readBool(iter *jsoniter.Iterator) (value, ok bool) {
+	var err error
+	switch iter.WhatIsNext() {
+	case jsoniter.BoolValue:
+		return iter.ReadBool(), true
+	case jsoniter.NilValue:
+		iter.ReadNil()
+		return false, false
+	case jsoniter.StringValue:
+		value, ok, err = parseBool(iter.ReadString())
+	case jsoniter.NumberValue:
+		value, ok, err = parseBool(string(iter.ReadNumber()))
+	default:
+		err = errors.Errorf("invalid zeek bool %s", iter.SkipAndReturnBytes())
+	}
+	if err != nil && iter.Error == nil {
+		iter.ReportError("decode zeek bool", err.Error())
+	}
+	return value, ok
+}
+
+// parseBool parses the text form of a Zeek `bool`.
+// Zeek writes booleans as `T` or `F` in TSV logs, other tools write `true`/`false` or `1`/`0`.
+// All the forms accepted by strconv.ParseBool are valid.
+// The unset value (`-`) returns false for `ok` without an error.
+func parseBool(value string) (b, ok bool, err error) {
+	if value == tsvDefaultUnsetField {
+		return false, false, nil
+	}
+	b, err = strconv.ParseBool(value)
+	if err != nil {
+		return false, false, errors.Errorf("invalid zeek bool %q", value)
+	}
+	return b, true, nil
+}
+
+// tagAliases is the struct tag listing the names a field had in previous Zeek versions
+const tagAliases = "aliases"
+
+// fieldAliases returns the alias names of a struct field
+func fieldAliases(tag reflect.StructTag) []string {
+	aliases := tag.Get(tagAliases)
+	if aliases == "" {
+		return nil
+	}
+	return strings.Split(aliases, ",")
+}
+
+// fieldAliasExtension decodes fields from their alias names so that logs of older sensors map to the same schema
+type fieldAliasExtension struct {
+	jsoniter.DummyExtension
+}
+
+func (*fieldAliasExtension) UpdateStructDescriptor(desc *jsoniter.StructDescriptor) {
+	for _, binding := range desc.Fields {
+		if len(binding.FromNames) == 0 {
+			continue
+		}
+		binding.FromNames = append(binding.FromNames, fieldAliases(binding.Field.Tag())...)
+	}
+}
+
+// fieldErrorExtension keeps track of the field that failed to decode
+type fieldErrorExtension struct {
+	jsoniter.DummyExtension
+}
+
+func (*fieldErrorExtension) UpdateStructDescriptor(desc *jsoniter.StructDescriptor) {
+	for _, binding := range desc.Fields {
+		if len(binding.FromNames) == 0 {
+			continue
+		}
+		binding.Decoder = &fieldErrorDecoder{
+			field:   binding.FromNames[0],
+			decoder: binding.Decoder,
+		}
+	}
+}
+
+// fieldErrorDecoder stores a *FieldError in the iterator attachment for the first field that fails to decode
+type fieldErrorDecoder struct {
+	field   string
+	decoder jsoniter.ValDecoder
+}
+
+func (d *fieldErrorDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	d.decoder.Decode(ptr, iter)
+	if iter.Error != nil && iter.Error != io.EOF && iter.Attachment == nil {
+		iter.Attachment = &FieldError{
+			Field: d.field,
+			Err:   iter.Error,
+		}
+	}
+}
+
+// checkTimeRange fails if the `ts` of an event is outside the time range
+func (d *logDecoder) checkTimeRange(event interface{}, bounds timeRange) error {
+	if bounds.isZero() {
+		return nil
+	}
+	v := reflect.ValueOf(event)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	v = v.Elem()
+	if d.ts == nil {
+		d.ts = findTSField(v.Type())
+	}
+	if len(d.ts) == 0 {
+		return nil
+	}
+	ts := v.FieldByIndex(d.ts).Interface().(*Time)
+	if ts == nil {
+		return nil
+	}
+	return bounds.check(time.Time(*ts))
+}
+
+// findTSField returns the index of the `ts` field of an event struct or an empty index if it has none
+func findTSField(typ reflect.Type) []int {
+	typTimePtr := reflect.PtrTo(typTime)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Type == typTimePtr && strings.Split(field.Tag.Get("json"), ",")[0] == "ts" {
+			return field.Index
+		}
+	}
+	return []int{}
+}
+
+// checkFields fails if a JSON object has fields that do not map to a field of `event`
+func (d *logDecoder) checkFields(data []byte, path string, event interface{}) error {
+	if d.fields == nil {
+		d.fields = map[string]reflect.Type{}
+		collectFieldTypesJSON(d.fields, reflect.TypeOf(event))
+	}
+	iter := jsoniter.ConfigDefault.BorrowIterator(data)
+	defer jsoniter.ConfigDefault.ReturnIterator(iter)
+	var unknown []string
+	iter.ReadObjectCB(func(iter *jsoniter.Iterator, key string) bool {
+		if _, ok := d.fields[key]; !ok {
+			unknown = append(unknown, key)
+		}
+		iter.Skip()
+		return true
+	})
+	if iter.Error != nil {
+		return iter.Error
+	}
+	if len(unknown) > 0 {
+		return errors.Errorf("zeek %s log has fields not in the schema: %s", path, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// bufferPool holds the buffers JSON log lines are copied to for decoding.
+// The decoded events do not reference the buffer (all strings are copied by jsoniter) so a buffer can be reused by
+// any goroutine as soon as Decode returns.
+var bufferPool = &sync.Pool{
+	New: func() interface{} {
+		return new([]byte)
+	},
+}
+
+// maxPooledBufferSize avoids holding on to the buffers of unusually long lines
+const maxPooledBufferSize = 64 * 1024
+
+func borrowBuffer() *[]byte {
+	return bufferPool.Get().(*[]byte)
+}
+
+func returnBuffer(buf *[]byte) {
+	if cap(*buf) > maxPooledBufferSize {
+		return
+	}
+	bufferPool.Put(buf)
+}
@@ -0,0 +1,228 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+)
+
+type testDecodeEvent struct {
+	Name    *string   `json:"name"`
+	Counts  []uint64  `json:"counts"`
+	Values  []float64 `json:"values"`
+	Strings []string  `json:"strings"`
+	Tags    Set       `json:"tags"`
+	Data    []byte    `json:"data"`
+}
+
+func TestDecodeScalarAsSlice(t *testing.T) {
+	d := logDecoder{}
+	event := testDecodeEvent{}
+	ok, err := d.Decode(`{"counts":42,"values":1.5,"strings":"foo","tags":"a,b","data":"Zm9v"}`, "test", &event, false, 0, timeRange{})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, testDecodeEvent{
+		Counts:  []uint64{42},
+		Values:  []float64{1.5},
+		Strings: []string{"foo"},
+		Tags:    Set{"a", "b"},
+		Data:    []byte("foo"),
+	}, event)
+
+	event = testDecodeEvent{}
+	_, err = d.Decode(`{"counts":[1,2],"values":null,"strings":["foo","bar"]}`, "test", &event, false, 0, timeRange{})
+	require.NoError(t, err)
+	require.Equal(t, testDecodeEvent{
+		Counts:  []uint64{1, 2},
+		Strings: []string{"foo", "bar"},
+	}, event)
+
+	_, err = d.Decode(`{"counts":"foo"}`, "test", &testDecodeEvent{}, false, 0, timeRange{})
+	require.Error(t, err)
+	_, err = d.Decode(`{"counts":{"foo":1}}`, "test", &testDecodeEvent{}, false, 0, timeRange{})
+	require.Error(t, err)
+}
+
+func TestDecodeBool(t *testing.T) {
+	type boolEvent struct {
+		Flag  *bool  `json:"flag"`
+		Flags []bool `json:"flags"`
+	}
+	for _, tc := range []struct {
+		Value  string
+		Expect *bool
+	}{
+		{`true`, aws.Bool(true)},
+		{`false`, aws.Bool(false)},
+		{`"T"`, aws.Bool(true)},
+		{`"F"`, aws.Bool(false)},
+		{`"true"`, aws.Bool(true)},
+		{`"false"`, aws.Bool(false)},
+		{`1`, aws.Bool(true)},
+		{`0`, aws.Bool(false)},
+		{`"1"`, aws.Bool(true)},
+		{`"0"`, aws.Bool(false)},
+		{`"-"`, nil},
+		{`null`, nil},
+	} {
+		event := boolEvent{}
+		ok, err := (&logDecoder{}).Decode(`{"flag":`+tc.Value+`}`, "test", &event, false, 0, timeRange{})
+		require.NoError(t, err, tc.Value)
+		require.True(t, ok)
+		require.Equal(t, tc.Expect, event.Flag, tc.Value)
+	}
+
+	event := boolEvent{}
+	_, err := (&logDecoder{}).Decode(`{"flags":["T","F",true,0]}`, "test", &event, false, 0, timeRange{})
+	require.NoError(t, err)
+	require.Equal(t, []bool{true, false, true, false}, event.Flags)
+
+	for _, value := range []string{`"yes"`, `""`, `2`, `{}`} {
+		_, err := (&logDecoder{}).Decode(`{"flag":`+value+`}`, "test", &boolEvent{}, false, 0, timeRange{})
+		require.Error(t, err, value)
+		fieldErr := &FieldError{}
+		require.True(t, errors.As(err, &fieldErr), value)
+		require.Equal(t, "flag", fieldErr.Field)
+	}
+}
+
+func TestDecodeBoolTSV(t *testing.T) {
+	type boolEvent struct {
+		Flag *bool `json:"flag"`
+	}
+	d := logDecoder{}
+	for _, line := range []string{
+		`#separator \x09`,
+		"#path\ttest",
+		"#fields\tflag",
+		"#types\tbool",
+	} {
+		_, err := d.Decode(line, "test", nil, false, 0, timeRange{})
+		require.NoError(t, err)
+	}
+	for _, tc := range []struct {
+		Value  string
+		Expect *bool
+	}{
+		{"T", aws.Bool(true)},
+		{"F", aws.Bool(false)},
+		{"true", aws.Bool(true)},
+		{"0", aws.Bool(false)},
+		{"-", nil},
+	} {
+		event := boolEvent{}
+		_, err := d.Decode(tc.Value, "test", &event, false, 0, timeRange{})
+		require.NoError(t, err, tc.Value)
+		require.Equal(t, tc.Expect, event.Flag, tc.Value)
+	}
+}
+
+func TestDecodeTimeRange(t *testing.T) {
+	type timeEvent struct {
+		TS      *Time `json:"ts"`
+		WriteTS *Time `json:"_write_ts"`
+	}
+	bounds := timeRange{
+		min: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		max: time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	d := logDecoder{}
+
+	event := timeEvent{}
+	_, err := d.Decode(`{"ts":1541001600.580233,"_write_ts":0.0}`, "test", &event, false, 0, bounds)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC), time.Time(*event.TS))
+
+	// Only the event time is checked
+	_, err = d.Decode(`{"_write_ts":0.0}`, "test", &timeEvent{}, false, 0, bounds)
+	require.NoError(t, err)
+
+	for _, log := range []string{
+		`{"ts":0.0}`,
+		`{"ts":4102444800.000001}`,
+	} {
+		_, err := d.Decode(log, "test", &timeEvent{}, false, 0, bounds)
+		require.Error(t, err, log)
+		rangeErr := &TimeRangeError{}
+		require.True(t, errors.As(err, &rangeErr), log)
+		require.Equal(t, bounds.min, rangeErr.MinTime)
+		require.Equal(t, bounds.max, rangeErr.MaxTime)
+		require.Contains(t, err.Error(), "out-of-range zeek timestamp")
+	}
+
+	// The offset is added before checking the range
+	_, err = d.Decode(`{"ts":946684799.0}`, "test", &timeEvent{}, false, time.Second, bounds)
+	require.NoError(t, err)
+
+	// The range is unbounded by default
+	_, err = d.Decode(`{"ts":0.0}`, "test", &timeEvent{}, false, 0, timeRange{})
+	require.NoError(t, err)
+	_, err = d.Decode(`{"ts":0.0}`, "test", &timeEvent{}, false, 0, timeRange{max: bounds.max})
+	require.NoError(t, err)
+}
+
+func TestDecodeDuplicateKeys(t *testing.T) {
+	d := logDecoder{}
+	event := testDecodeEvent{}
+	// nolint:lll
+	_, err := d.Decode(`{"name":"foo","counts":[1,2],"values":3,"tags":["a"],"name":"bar","counts":[3],"values":[4,5],"tags":"b,c","strings":["foo"],"strings":null}`, "test", &event, false, 0, timeRange{})
+	require.NoError(t, err)
+	require.Equal(t, testDecodeEvent{
+		Name:   aws.String("bar"),
+		Counts: []uint64{3},
+		Values: []float64{4, 5},
+		Tags:   Set{"b", "c"},
+	}, event)
+
+	// Duplicate keys are not unknown fields in strict mode
+	_, err = d.Decode(`{"name":"foo","name":"bar"}`, "test", &testDecodeEvent{}, true, 0, timeRange{})
+	require.NoError(t, err)
+}
+
+func TestZeekDNSScalarTTL(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","query":"www.example.com","answers":"93.184.216.34","TTLs":60.0}`
+	logs, err := (&ZeekDNSParser{}).Parse(log)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	event := logs[0].Event().(*ZeekDNS)
+	require.Equal(t, StringArray{"93.184.216.34"}, event.Answers)
+	require.Equal(t, []float64{60}, event.TTLs)
+}
+
+func TestDecodeFieldError(t *testing.T) {
+	d := logDecoder{}
+	_, err := d.Decode(`{"name":"foo","counts":[1,-2]}`, "test", &testDecodeEvent{}, false, 0, timeRange{})
+	require.Error(t, err)
+	fieldErr, ok := err.(*FieldError)
+	require.True(t, ok)
+	require.Equal(t, "counts", fieldErr.Field)
+
+	_, err = d.Decode(`{"name":"foo"} {"name":"bar"}`, "test", &testDecodeEvent{}, false, 0, timeRange{})
+	require.Error(t, err)
+	_, err = d.Decode(`{"name":"foo"`, "test", &testDecodeEvent{}, false, 0, timeRange{})
+	require.Error(t, err)
+	_, err = d.Decode(`{"name":"foo"}  `, "test", &testDecodeEvent{}, false, 0, timeRange{})
+	require.NoError(t, err)
+}
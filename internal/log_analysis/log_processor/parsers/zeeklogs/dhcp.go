@@ -0,0 +1,132 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekDHCP struct {
+	TS             *Time    `json:"ts,omitempty" validate:"required" description:"The earliest time at which a DHCP message over the associated connection is observed."`
+	UIDs           Set      `json:"uids,omitempty" description:"A series of unique identifiers of the connections over which DHCP is occurring. This behavior with multiple connections is unique to DHCP because of the way it uses broadcast packets on local networks."`
+	ClientAddr     *string  `json:"client_addr,omitempty" panther:"ip" description:"IP address of the client. If a transaction is only a client sending INFORM messages then there is no lease information exchanged so this is helpful to know who sent the messages."`
+	ServerAddr     *string  `json:"server_addr,omitempty" panther:"ip" description:"IP address of the server involved in actually handing out the lease."`
+	ClientPort     *uint16  `json:"client_port,omitempty" description:"Client port number seen at time of server handing out IP."`
+	ServerPort     *uint16  `json:"server_port,omitempty" description:"Server port number seen at time of server handing out IP."`
+	MAC            *string  `json:"mac,omitempty" panther:"mac" description:"Client’s hardware address."`
+	HostName       *string  `json:"host_name,omitempty" panther:"domain" description:"Name given by client in Hostname option 12."`
+	ClientFQDN     *string  `json:"client_fqdn,omitempty" panther:"domain" description:"FQDN given by client in Client FQDN option 81."`
+	Domain         *string  `json:"domain,omitempty" description:"Domain given by the server in option 15."`
+	RequestedAddr  *string  `json:"requested_addr,omitempty" panther:"ip" description:"IP address requested by the client."`
+	AssignedAddr   *string  `json:"assigned_addr,omitempty" panther:"ip" description:"IP address assigned by the server."`
+	LeaseTime      *float64 `json:"lease_time,omitempty" description:"IP address lease interval."`
+	ClientMessage  *string  `json:"client_message,omitempty" description:"Message typically accompanied with a DHCP_DECLINE so the client can tell the server why it rejected an address."`
+	ServerMessage  *string  `json:"server_message,omitempty" description:"Message typically accompanied with a DHCP_NAK to let the client know why it rejected the request."`
+	MsgTypes       Set      `json:"msg_types,omitempty" description:"The DHCP message types seen by this DHCP transaction."`
+	Duration       *float64 `json:"duration,omitempty" description:"Duration of the DHCP “session” representing the time from the first message to the last."`
+	DurationMillis *float64 `json:"duration_ms,omitempty" description:"Duration of the DHCP session in milliseconds, only set if the parser has Milliseconds enabled."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekDHCPParser parses zeek dhcp logs
+type ZeekDHCPParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	// Milliseconds adds a field with the value in milliseconds next to each duration field in seconds
+	Milliseconds bool
+	decoder      logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekDHCPParser)(nil)
+
+func (p *ZeekDHCPParser) New() parsers.LogParser {
+	return &ZeekDHCPParser{
+		Strict:       p.Strict,
+		TimeOffset:   p.TimeOffset,
+		MinTime:      p.MinTime,
+		MaxTime:      p.MaxTime,
+		Indicators:   p.Indicators,
+		Milliseconds: p.Milliseconds,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekDHCPParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekDHCP := &ZeekDHCP{}
+
+	ok, err := p.decoder.Decode(log, pathDHCP, zeekDHCP, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	if zeekDHCP.MAC != nil {
+		*zeekDHCP.MAC = normalizeMAC(*zeekDHCP.MAC)
+	}
+	if p.Milliseconds {
+		zeekDHCP.DurationMillis = secondsToMillis(zeekDHCP.Duration)
+	}
+	zeekDHCP.updatePantherFields(p)
+	p.Indicators.apply(&zeekDHCP.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekDHCP); err != nil {
+		return nil, err
+	}
+
+	return zeekDHCP.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekDHCPParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekDHCPParser) LogType() string {
+	return TypeZeekDHCP
+}
+
+func (event *ZeekDHCP) updatePantherFields(p *ZeekDHCPParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "client_addr", event.ClientAddr)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "server_addr", event.ServerAddr)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "requested_addr", event.RequestedAddr)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "assigned_addr", event.AssignedAddr)
+
+	appendDomainNamePtr(&event.ZeekPantherLog, event.HostName)
+	appendDomainNamePtr(&event.ZeekPantherLog, event.ClientFQDN)
+
+	appendMACAddressPtr(&event.ZeekPantherLog, event.MAC)
+}
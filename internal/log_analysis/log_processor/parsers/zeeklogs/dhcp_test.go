@@ -0,0 +1,118 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekDHCP(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uids":["CmWOt6VWaNGqXYcH6","CLObLo4YHn0u23Tp8a"],"client_addr":"192.168.199.132","server_addr":"192.168.199.254","mac":"00:0c:29:03:df:ad","host_name":"DESKTOP-2AEFM7G","client_fqdn":"DESKTOP-2AEFM7G","domain":"localdomain","requested_addr":"192.168.199.132","assigned_addr":"192.168.199.132","lease_time":1800.0,"msg_types":["REQUEST","ACK"],"duration":0.000133}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekDHCP{
+		TS:            (*Time)(&expectedTime),
+		UIDs:          []string{"CmWOt6VWaNGqXYcH6", "CLObLo4YHn0u23Tp8a"},
+		ClientAddr:    aws.String("192.168.199.132"),
+		ServerAddr:    aws.String("192.168.199.254"),
+		MAC:           aws.String("00:0c:29:03:df:ad"),
+		HostName:      aws.String("DESKTOP-2AEFM7G"),
+		ClientFQDN:    aws.String("DESKTOP-2AEFM7G"),
+		Domain:        aws.String("localdomain"),
+		RequestedAddr: aws.String("192.168.199.132"),
+		AssignedAddr:  aws.String("192.168.199.132"),
+		LeaseTime:     aws.Float64(1800),
+		MsgTypes:      []string{"REQUEST", "ACK"},
+		Duration:      aws.Float64(0.000133),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.DHCP")
+	expectedEvent.AppendAnyIPAddress("192.168.199.132")
+	expectedEvent.AppendAnyIPAddress("192.168.199.254")
+	expectedEvent.AppendAnyMACAddresses("00:0c:29:03:df:ad")
+	expectedEvent.AppendAnyDomainNames("desktop-2aefm7g")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekDHCP(t, log, expectedEvent)
+}
+
+func TestZeekDHCPMACWithoutColons(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uids":["CmWOt6VWaNGqXYcH6"],"client_addr":"192.168.199.132","mac":"000C2903DFAD","msg_types":["INFORM"]}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekDHCP{
+		TS:         (*Time)(&expectedTime),
+		UIDs:       []string{"CmWOt6VWaNGqXYcH6"},
+		ClientAddr: aws.String("192.168.199.132"),
+		MAC:        aws.String("00:0c:29:03:df:ad"),
+		MsgTypes:   []string{"INFORM"},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.DHCP")
+	expectedEvent.AppendAnyIPAddress("192.168.199.132")
+	expectedEvent.AppendAnyMACAddresses("00:0c:29:03:df:ad")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekDHCP(t, log, expectedEvent)
+}
+
+func TestNormalizeMAC(t *testing.T) {
+	for input, expect := range map[string]string{
+		"00:0c:29:03:df:ad": "00:0c:29:03:df:ad",
+		"00:0C:29:03:DF:AD": "00:0c:29:03:df:ad",
+		"000c2903dfad":      "00:0c:29:03:df:ad",
+		"00-0C-29-03-DF-AD": "00:0c:29:03:df:ad",
+		"000c.2903.dfad":    "00:0c:29:03:df:ad",
+		"AABBCCDDEEFF":      "aa:bb:cc:dd:ee:ff",
+		"00:0c:29":          "00:0c:29",
+		"not a mac":         "not a mac",
+		"":                  "",
+	} {
+		require.Equal(t, expect, normalizeMAC(input), input)
+	}
+	// All forms of an address collapse to a single indicator value
+	pl := &ZeekPantherLog{}
+	for _, mac := range []string{"AA-BB-CC-DD-EE-FF", "aabbccddeeff", "aabb.ccdd.eeff", "not a mac"} {
+		appendMACAddress(pl, mac)
+	}
+	expect := &ZeekPantherLog{}
+	expect.AppendAnyMACAddresses("aa:bb:cc:dd:ee:ff")
+	require.Equal(t, expect, pl)
+}
+
+func TestZeekDHCPType(t *testing.T) {
+	parser := &ZeekDHCPParser{}
+	require.Equal(t, "Zeek.DHCP", parser.LogType())
+}
+
+func checkZeekDHCP(t *testing.T, log string, expectedEvent *ZeekDHCP) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekDHCPParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
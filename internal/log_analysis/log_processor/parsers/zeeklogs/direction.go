@@ -0,0 +1,97 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+// The directions of a connection relative to the local networks
+const (
+	DirectionInbound  = "inbound"
+	DirectionOutbound = "outbound"
+	DirectionInternal = "internal"
+	DirectionExternal = "external"
+)
+
+// Networks is a set of CIDR ranges
+type Networks []*net.IPNet
+
+// ParseNetworks parses a set of CIDR ranges (i.e. `10.0.0.0/8`)
+func ParseNetworks(cidrs ...string) (Networks, error) {
+	networks := make(Networks, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid local network %q", cidr)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// Contains checks if an IP address is in any of the networks
+func (n Networks) Contains(ip net.IP) bool {
+	for _, network := range n {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultLocalNetworks are the private address ranges of RFC 1918
+var defaultLocalNetworks = mustParseNetworks("10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16")
+
+func mustParseNetworks(cidrs ...string) Networks {
+	networks, err := ParseNetworks(cidrs...)
+	if err != nil {
+		panic(err)
+	}
+	return networks
+}
+
+// connDirection classifies a connection by whether its originator and responder are in the local networks.
+// It returns nil if either address is missing or invalid.
+func connDirection(local Networks, origH, respH *string) *string {
+	if origH == nil || respH == nil {
+		return nil
+	}
+	orig, resp := net.ParseIP(*origH), net.ParseIP(*respH)
+	if orig == nil || resp == nil {
+		return nil
+	}
+	if local == nil {
+		local = defaultLocalNetworks
+	}
+	var direction string
+	switch origLocal, respLocal := local.Contains(orig), local.Contains(resp); {
+	case origLocal && respLocal:
+		direction = DirectionInternal
+	case origLocal:
+		direction = DirectionOutbound
+	case respLocal:
+		direction = DirectionInbound
+	default:
+		direction = DirectionExternal
+	}
+	return &direction
+}
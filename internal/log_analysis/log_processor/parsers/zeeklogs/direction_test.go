@@ -0,0 +1,72 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnDirection(t *testing.T) {
+	for _, tc := range []struct {
+		Orig, Resp *string
+		Expect     *string
+	}{
+		{aws.String("10.0.0.1"), aws.String("192.168.1.10"), aws.String(DirectionInternal)},
+		{aws.String("172.16.2.16"), aws.String("93.184.216.34"), aws.String(DirectionOutbound)},
+		{aws.String("203.0.113.7"), aws.String("172.31.255.1"), aws.String(DirectionInbound)},
+		{aws.String("203.0.113.7"), aws.String("93.184.216.34"), aws.String(DirectionExternal)},
+		{aws.String("::ffff:10.1.2.3"), aws.String("2001:db8::1"), aws.String(DirectionOutbound)},
+		{aws.String("172.32.0.1"), aws.String("10.0.0.1"), aws.String(DirectionInbound)},
+		{nil, aws.String("10.0.0.1"), nil},
+		{aws.String("10.0.0.1"), nil, nil},
+		{aws.String("10.0.0.1"), aws.String("-"), nil},
+	} {
+		require.Equal(t, tc.Expect, connDirection(nil, tc.Orig, tc.Resp), "%v -> %v", aws.StringValue(tc.Orig), aws.StringValue(tc.Resp))
+	}
+}
+
+func TestConnDirectionLocalNetworks(t *testing.T) {
+	local, err := ParseNetworks("100.64.0.0/10", "fd00::/8")
+	require.NoError(t, err)
+	require.Equal(t, aws.String(DirectionInternal), connDirection(local, aws.String("100.64.1.1"), aws.String("fd12:3456::1")))
+	require.Equal(t, aws.String(DirectionOutbound), connDirection(local, aws.String("100.64.1.1"), aws.String("10.0.0.1")))
+	require.Equal(t, aws.String(DirectionInbound), connDirection(local, aws.String("192.168.1.1"), aws.String("100.127.0.1")))
+
+	_, err = ParseNetworks("10.0.0.0/8", "10.0.0.1")
+	require.Error(t, err)
+}
+
+func TestZeekConnDirection(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CMMSD31ztelbBMQi31","id.orig_h":"100.64.10.2","id.orig_p":48382,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp"}`
+
+	logs, err := (&ZeekConnParser{}).Parse(log)
+	require.NoError(t, err)
+	require.Equal(t, aws.String(DirectionInbound), logs[0].Event().(*ZeekConn).Direction)
+
+	local, err := ParseNetworks("100.64.0.0/10")
+	require.NoError(t, err)
+	parser := (&ZeekConnParser{LocalNetworks: local}).New()
+	logs, err = parser.Parse(log)
+	require.NoError(t, err)
+	require.Equal(t, aws.String(DirectionOutbound), logs[0].Event().(*ZeekConn).Direction)
+}
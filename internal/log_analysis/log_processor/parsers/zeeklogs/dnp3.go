@@ -0,0 +1,107 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekDNP3 struct {
+	TS        *Time   `json:"ts,omitempty" validate:"required" description:"Time of the request."`
+	UID       *string `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection."`
+	IDOrigH   *string `json:"id.orig_h" panther:"ip" validate:"required" description:"The originator’s IP address."`
+	IDOrigP   *uint16 `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
+	IDRespH   *string `json:"id.resp_h" panther:"ip" validate:"required" description:"The responder’s IP address."`
+	IDRespP   *uint16 `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
+	FCRequest *string `json:"fc_request,omitempty" description:"The name of the function message in the request."`
+	FCReply   *string `json:"fc_reply,omitempty" description:"The name of the function message in the reply."`
+	IIN       *uint64 `json:"iin,omitempty" description:"The response’s internal indication number."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekDNP3Parser parses zeek dnp3 logs
+type ZeekDNP3Parser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekDNP3Parser)(nil)
+
+func (p *ZeekDNP3Parser) New() parsers.LogParser {
+	return &ZeekDNP3Parser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekDNP3Parser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekDNP3 := &ZeekDNP3{}
+
+	ok, err := p.decoder.Decode(log, pathDNP3, zeekDNP3, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekDNP3.updatePantherFields(p)
+	p.Indicators.apply(&zeekDNP3.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekDNP3); err != nil {
+		return nil, err
+	}
+
+	return zeekDNP3.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekDNP3Parser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekDNP3Parser) LogType() string {
+	return TypeZeekDNP3
+}
+
+func (event *ZeekDNP3) updatePantherFields(p *ZeekDNP3Parser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+}
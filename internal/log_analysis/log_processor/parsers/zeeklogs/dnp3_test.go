@@ -0,0 +1,73 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestZeekDNP3(t *testing.T) {
+	checkZeekFixture(t, zeekFixture{
+		LogType: TypeZeekDNP3,
+		// nolint:lll
+		Log: `{"ts":1541001600.580233,"uid":"CGnF5B3pxv8Lw6Aw2h","id.orig_h":"10.20.0.5","id.orig_p":1159,"id.resp_h":"10.20.1.8","id.resp_p":20000,"fc_request":"WRITE"}`,
+		Expect: `{
+			"ts": "2018-10-31 16:00:00.580233000",
+			"uid": "CGnF5B3pxv8Lw6Aw2h",
+			"id.orig_h": "10.20.0.5",
+			"id.orig_p": 1159,
+			"id.resp_h": "10.20.1.8",
+			"id.resp_p": 20000,
+			"fc_request": "WRITE",
+			"p_log_type": "Zeek.DNP3",
+			"p_event_time": "2018-10-31 16:00:00.580233000",
+			"p_any_ip_addresses": ["10.20.0.5", "10.20.1.8"]
+		}`,
+		Golden: "dnp3",
+	})
+}
+
+func TestZeekDNP3Reply(t *testing.T) {
+	checkZeekFixture(t, zeekFixture{
+		LogType: TypeZeekDNP3,
+		// nolint:lll
+		Log: `{"ts":1541001600.580233,"uid":"CGnF5B3pxv8Lw6Aw2h","id.orig_h":"10.20.0.5","id.orig_p":1159,"id.resp_h":"10.20.1.8","id.resp_p":20000,"fc_request":"READ","fc_reply":"RESPONSE","iin":36864}`,
+		Expect: `{
+			"ts": "2018-10-31 16:00:00.580233000",
+			"uid": "CGnF5B3pxv8Lw6Aw2h",
+			"id.orig_h": "10.20.0.5",
+			"id.orig_p": 1159,
+			"id.resp_h": "10.20.1.8",
+			"id.resp_p": 20000,
+			"fc_request": "READ",
+			"fc_reply": "RESPONSE",
+			"iin": 36864,
+			"p_log_type": "Zeek.DNP3",
+			"p_event_time": "2018-10-31 16:00:00.580233000",
+			"p_any_ip_addresses": ["10.20.0.5", "10.20.1.8"]
+		}`,
+	})
+}
+
+func TestZeekDNP3Type(t *testing.T) {
+	parser := &ZeekDNP3Parser{}
+	require.Equal(t, "Zeek.DNP3", parser.LogType())
+}
@@ -19,71 +19,119 @@ package zeeklogs
  */
 
 import (
-	jsoniter "github.com/json-iterator/go"
+	"strings"
+	"time"
 
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
 )
 
-// https://docs.zeek.org/en/current/scripts/base/protocols/dns/consts.zeek.html#id-DNS::query_types
-const (
-	aQueryType    = uint64(1)
-	aaaaQueryType = uint64(28)
-)
-
 // nolint:lll
 type ZeekDNS struct {
-	TS         *timestamp.UnixFloat `json:"ts,omitempty" validate:"required" description:"The earliest time at which a DNS protocol message over the associated connection is observed."`
-	UID        *string              `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection over which DNS messages are being transferred."`
-	IDOrigH    *string              `json:"id.orig_h" validate:"required" description:"The originator’s IP address."`
-	IDOrigP    *uint16              `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
-	IDRespH    *string              `json:"id.resp_h" validate:"required" description:"The responder’s IP address."`
-	IDRespP    *uint16              `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
-	Proto      *string              `json:"proto" validate:"required" description:"The transport layer protocol of the connection."`
-	TransID    *uint16              `json:"trans_id,omitempty" description:"A 16-bit identifier assigned by the program that generated the DNS query. Also used in responses to match up replies to outstanding queries."`
-	Query      *string              `json:"query,omitempty" description:"The domain name that is the subject of the DNS query."`
-	QClass     *uint64              `json:"qclass,omitempty" description:"The QCLASS value specifying the class of the query."`
-	QClassName *string              `json:"qclass_name,omitempty" description:"A descriptive name for the class of the query."`
-	QType      *uint64              `json:"qtype,omitempty" description:"A QTYPE value specifying the type of the query."`
-	QTypeName  *string              `json:"qtype_name,omitempty" description:"A descriptive name for the type of the query."`
-	Rcode      *uint64              `json:"rcode,omitempty" description:"The response code value in DNS response messages."`
-	RcodeName  *string              `json:"rcode_name" description:"A descriptive name for the response code value."`
-	AA         *bool                `json:"AA,omitempty" description:"The Authoritative Answer bit for response messages specifies that the responding name server is an authority for the domain name in the question section."`
-	TC         *bool                `json:"TC,omitempty" description:"The Truncation bit specifies that the message was truncated."`
-	RD         *bool                `json:"RD,omitempty" description:"The Recursion Desired bit in a request message indicates that the client wants recursive service for this query."`
-	RA         *bool                `json:"RA,omitempty" description:"The Recursion Available bit in a response message indicates that the name server supports recursive queries."`
-	Z          *int                 `json:"Z,omitempty" description:"A reserved field that is usually zero in queries and responses."`
-	Answers    []string             `json:"answers,omitempty" description:"The set of resource descriptions in the query answer."`
-	TTLs       []float64            `json:"TTLs,omitempty" description:"The caching intervals (measured in seconds) of the associated RRs described by the answers field."`
-	Rejected   *bool                `json:"rejected,omitempty" description:"The DNS query was rejected by the server."`
-	parsers.PantherLog
+	TS          *Time       `json:"ts,omitempty" validate:"required" description:"The earliest time at which a DNS protocol message over the associated connection is observed."`
+	UID         *string     `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection over which DNS messages are being transferred."`
+	IDOrigH     *string     `json:"id.orig_h" panther:"ip" validate:"required" description:"The originator’s IP address."`
+	IDOrigP     *uint16     `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
+	IDRespH     *string     `json:"id.resp_h" panther:"ip" validate:"required" description:"The responder’s IP address."`
+	IDRespP     *uint16     `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
+	Proto       *string     `json:"proto" validate:"required" description:"The transport layer protocol of the connection."`
+	TransID     *uint16     `json:"trans_id,omitempty" description:"A 16-bit identifier assigned by the program that generated the DNS query. Also used in responses to match up replies to outstanding queries."`
+	RTT         *float64    `json:"rtt,omitempty" description:"Round trip time for the query and response. This indicates the delay between when the request was seen until the answer started."`
+	RTTMillis   *float64    `json:"rtt_ms,omitempty" description:"The round trip time of the query and response in milliseconds, only set if the parser has Milliseconds enabled."`
+	Query       *string     `json:"query,omitempty" panther:"domain" description:"The domain name that is the subject of the DNS query."`
+	QClass      *uint64     `json:"qclass,omitempty" description:"The QCLASS value specifying the class of the query."`
+	QClassName  *string     `json:"qclass_name,omitempty" description:"A descriptive name for the class of the query."`
+	QType       *uint64     `json:"qtype,omitempty" description:"A QTYPE value specifying the type of the query."`
+	QTypeName   *string     `json:"qtype_name,omitempty" description:"A descriptive name for the type of the query."`
+	Rcode       *uint64     `json:"rcode,omitempty" description:"The response code value in DNS response messages."`
+	RcodeName   *string     `json:"rcode_name" description:"A descriptive name for the response code value."`
+	AA          *bool       `json:"AA,omitempty" aliases:"aa" description:"The Authoritative Answer bit for response messages specifies that the responding name server is an authority for the domain name in the question section."`
+	TC          *bool       `json:"TC,omitempty" aliases:"tc" description:"The Truncation bit specifies that the message was truncated."`
+	RD          *bool       `json:"RD,omitempty" aliases:"rd" description:"The Recursion Desired bit in a request message indicates that the client wants recursive service for this query."`
+	RA          *bool       `json:"RA,omitempty" aliases:"ra" description:"The Recursion Available bit in a response message indicates that the name server supports recursive queries."`
+	Z           *int        `json:"Z,omitempty" aliases:"z" description:"A reserved field that is usually zero in queries and responses."`
+	Answers     StringArray `json:"answers,omitempty" panther:"hostname" description:"The set of resource descriptions in the query answer."`
+	TTLs        []float64   `json:"TTLs,omitempty" description:"The caching intervals (measured in seconds) of the associated RRs described by the answers field."`
+	Rejected    *bool       `json:"rejected,omitempty" description:"The DNS query was rejected by the server."`
+	CommunityID *string     `json:"community_id,omitempty" description:"The Community ID hash of the connection 5-tuple (https://github.com/corelight/community-id-spec)."`
+	Direction   *string     `json:"direction,omitempty" description:"Direction of the connection relative to the local networks of the parser (inbound, outbound, internal or external)."`
+	ZeekMeta
+	ZeekPantherLog
 }
 
 // ZeekDNSParser parses zeek dns logs
-type ZeekDNSParser struct{}
+type ZeekDNSParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	// LocalNetworks are the internal networks used to set the direction of connections, RFC 1918 ranges by default
+	LocalNetworks Networks
+	// Milliseconds adds a field with the value in milliseconds next to each duration field in seconds
+	Milliseconds bool
+	decoder      logDecoder
+}
 
 var _ parsers.LogParser = (*ZeekDNSParser)(nil)
 
 func (p *ZeekDNSParser) New() parsers.LogParser {
-	return &ZeekDNSParser{}
+	return &ZeekDNSParser{
+		Strict:        p.Strict,
+		TimeOffset:    p.TimeOffset,
+		MinTime:       p.MinTime,
+		MaxTime:       p.MaxTime,
+		Indicators:    p.Indicators,
+		Milliseconds:  p.Milliseconds,
+		LocalNetworks: p.LocalNetworks,
+	}
 }
 
 // Parse returns the parsed events or nil if parsing failed
 func (p *ZeekDNSParser) Parse(log string) ([]*parsers.PantherLog, error) {
-	zeekDNS := &ZeekDNS{}
+	result := &zeekDNSResult{}
+	zeekDNS := &result.event
 
-	err := jsoniter.UnmarshalFromString(log, zeekDNS)
+	ok, err := p.decoder.Decode(log, pathDNS, zeekDNS, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
 	if err != nil {
 		return nil, err
 	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
 
+	zeekDNS.setCodeNames()
+	zeekDNS.setCommunityID()
+	zeekDNS.Direction = connDirection(p.LocalNetworks, zeekDNS.IDOrigH, zeekDNS.IDRespH)
+	if p.Milliseconds {
+		zeekDNS.RTTMillis = secondsToMillis(zeekDNS.RTT)
+	}
 	zeekDNS.updatePantherFields(p)
+	p.Indicators.apply(&zeekDNS.ZeekPantherLog)
 
 	if err := parsers.Validator.Struct(zeekDNS); err != nil {
 		return nil, err
 	}
 
-	return zeekDNS.Logs(), nil
+	result.logs[0] = &zeekDNS.PantherLog
+	return result.logs[:], nil
+}
+
+// zeekDNSResult allocates a DNS event together with the result slice returned for it.
+// Both are owned by the caller once Parse returns so, unlike the decoding buffers, they are never reused.
+type zeekDNSResult struct {
+	event ZeekDNS
+	logs  [1]*parsers.PantherLog
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekDNSParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
 }
 
 // LogType returns the log type supported by this parser
@@ -91,22 +139,41 @@ func (p *ZeekDNSParser) LogType() string {
 	return TypeZeekDNS
 }
 
+// setCodeNames sets the names of numeric codes for logs that only contain the numeric values
+func (event *ZeekDNS) setCodeNames() {
+	if name := lookupCodeName(dnsQTypeNames, event.QType); name != nil && !isSetPtr(event.QTypeName) {
+		event.QTypeName = name
+	}
+	if name := lookupCodeName(dnsRcodeNames, event.Rcode); name != nil && !isSetPtr(event.RcodeName) {
+		event.RcodeName = name
+	}
+}
+
+// setCommunityID computes the Community ID of the connection unless it was logged by Zeek
+func (event *ZeekDNS) setCommunityID() {
+	if event.CommunityID == nil {
+		event.CommunityID = communityIDPtr(event.IDOrigH, event.IDOrigP, event.IDRespH, event.IDRespP, event.Proto)
+	}
+}
+
 func (event *ZeekDNS) updatePantherFields(p *ZeekDNSParser) {
 	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
 
-	event.AppendAnyIPAddressPtr(event.IDOrigH)
-	event.AppendAnyIPAddressPtr(event.IDRespH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
 
-	if event.QType != nil && (*event.QType == aQueryType || *event.QType == aaaaQueryType) {
-		if event.Query != nil {
-			event.AppendAnyDomainNames(*event.Query)
-		}
-	}
+	appendDomainNamePtr(&event.ZeekPantherLog, event.Query)
 
-	for _, answer := range event.Answers {
+	for i, answer := range event.Answers {
 		// Answer might be IP or Domain name
-		if !event.AppendAnyIPAddress(answer) {
-			event.AppendAnyDomainNames(answer)
+		if _, ok := canonicalIPAddress(answer); ok {
+			appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "answers", &event.Answers[i])
+			continue
+		}
+		// Answers for records such as TXT are free form text and not domain names
+		if strings.ContainsAny(answer, " \t") {
+			continue
 		}
+		appendDomainName(&event.ZeekPantherLog, answer)
 	}
 }
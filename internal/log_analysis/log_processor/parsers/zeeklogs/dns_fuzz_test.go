@@ -0,0 +1,58 @@
+//go:build go1.18
+// +build go1.18
+
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"testing"
+)
+
+// FuzzZeekDNSParser checks that the DNS parser returns an error instead of panicking for any input.
+// Each input is split to lines that are parsed in order so that TSV headers apply to the rows that follow them.
+// Native fuzzing requires Go 1.18, run it with `go test -run=^$ -fuzz=FuzzZeekDNSParser`.
+// Edge cases of the decoder (mismatched answers and TTLs, out of range numbers, malformed TSV headers) are kept as
+// regression seeds in testdata/fuzz/FuzzZeekDNSParser.
+func FuzzZeekDNSParser(f *testing.F) {
+	// nolint:lll
+	for _, seed := range []string{
+		`{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","trans_id":27282,"query":"www.example.com","qtype":1,"qtype_name":"A","rcode":0,"rcode_name":"NOERROR","AA":false,"TC":false,"RD":true,"RA":true,"Z":0,"answers":["www.example.com.cdn.net","93.184.216.34"],"TTLs":[300.0,60.0],"rejected":false,"rtt":0.022}`,
+		`{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","query":"example.com","answers":"93.184.216.34","TTLs":60}`,
+		"#separator \\x09\n#set_separator\t,\n#empty_field\t(empty)\n#unset_field\t-\n#path\tdns\n" +
+			"#fields\tts\tuid\tid.orig_h\tid.orig_p\tid.resp_h\tid.resp_p\tproto\ttrans_id\tquery\tqtype\trcode\tAA\tanswers\tTTLs\n" +
+			"#types\ttime\tstring\taddr\tport\taddr\tport\tenum\tcount\tstring\tcount\tcount\tbool\tvector[string]\tvector[interval]\n" +
+			"1541001600.580233\tCpR9AY39cUCZ0t5qq6\t172.16.2.16\t43720\t172.16.0.2\t53\tudp\t27282\twww.example.com\t1\t0\tF\t93.184.216.34,www.example.com\t60.000000,300.000000",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		parser := (&ZeekDNSParser{
+			Strict:       len(input)%2 == 0,
+			Milliseconds: true,
+		}).New()
+		for _, line := range strings.Split(input, "\n") {
+			logs, err := parser.Parse(line)
+			if err != nil && logs != nil {
+				t.Fatalf("parser returned both events and an error for %q", line)
+			}
+		}
+	})
+}
@@ -19,42 +19,116 @@ package zeeklogs
  */
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/require"
 
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
 )
 
 func TestZeekDNS(t *testing.T) {
+	checkZeekFixture(t, zeekFixture{
+		LogType: TypeZeekDNS,
+		// nolint:lll
+		Log: `{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","trans_id":27282,"query":"16.2.16.172.in-addr.arpa", "qtype":1,"rcode":0,"rcode_name":"NOERROR","AA":false,"TC":false,"RD":false,"RA":true,"Z":0,"answers":["ip-172-16-2-16.us-west-2.compute.internal"],"TTLs":[60.0],"rejected":false}`,
+		Expect: `{
+			"ts": "2018-10-31 16:00:00.580233000",
+			"uid": "CpR9AY39cUCZ0t5qq6",
+			"id.orig_h": "172.16.2.16",
+			"id.orig_p": 43720,
+			"id.resp_h": "172.16.0.2",
+			"id.resp_p": 53,
+			"proto": "udp",
+			"trans_id": 27282,
+			"query": "16.2.16.172.in-addr.arpa",
+			"qtype": 1,
+			"qtype_name": "A",
+			"rcode": 0,
+			"rcode_name": "NOERROR",
+			"AA": false,
+			"TC": false,
+			"RD": false,
+			"RA": true,
+			"Z": 0,
+			"answers": ["ip-172-16-2-16.us-west-2.compute.internal"],
+			"TTLs": [60],
+			"rejected": false,
+			"community_id": "1:6V3+ZMlJEyu3Eir1iRtLAUmn7n0=",
+			"direction": "internal",
+			"p_log_type": "Zeek.DNS",
+			"p_event_time": "2018-10-31 16:00:00.580233000",
+			"p_any_ip_addresses": ["172.16.0.2", "172.16.2.16"],
+			"p_any_domain_names": ["16.2.16.172.in-addr.arpa", "ip-172-16-2-16.us-west-2.compute.internal"]
+		}`,
+		Golden: "dns",
+	})
+}
+
+func TestZeekDNSMultipleAnswers(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","trans_id":27282,"query":"www.example.com","qtype":1,"qtype_name":"A","rcode":0,"rcode_name":"NOERROR","answers":["www.example.com.cdn.net","93.184.216.34","2606:2800:220:1:248:1893:25c8:1946","TXT 15 v=spf1 -all"],"TTLs":[300.0,60.0,60.0,120.0]}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekDNS{
+		TS:          (*Time)(&expectedTime),
+		UID:         aws.String("CpR9AY39cUCZ0t5qq6"),
+		IDOrigH:     aws.String("172.16.2.16"),
+		IDOrigP:     aws.Uint16(43720),
+		IDRespH:     aws.String("172.16.0.2"),
+		IDRespP:     aws.Uint16(53),
+		Proto:       aws.String("udp"),
+		CommunityID: aws.String("1:6V3+ZMlJEyu3Eir1iRtLAUmn7n0="),
+		TransID:     aws.Uint16(27282),
+		Query:       aws.String("www.example.com"),
+		QType:       aws.Uint64(1),
+		QTypeName:   aws.String("A"),
+		Rcode:       aws.Uint64(0),
+		RcodeName:   aws.String("NOERROR"),
+		Answers:     []string{"www.example.com.cdn.net", "93.184.216.34", "2606:2800:220:1:248:1893:25c8:1946", "TXT 15 v=spf1 -all"},
+		TTLs:        []float64{300.0, 60.0, 60.0, 120.0},
+		Direction:   aws.String("internal"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.DNS")
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDOrigH)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDRespH)
+	expectedEvent.AppendAnyIPAddress("93.184.216.34")
+	expectedEvent.AppendAnyIPAddress("2606:2800:220:1:248:1893:25c8:1946")
+	expectedEvent.AppendAnyDomainNames("www.example.com", "www.example.com.cdn.net")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekDNS(t, log, expectedEvent)
+}
+
+func TestZeekDNSAnswersWithoutTTLs(t *testing.T) {
 	// nolint:lll
-	log := `{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","trans_id":27282,"query":"16.2.16.172.in-addr.arpa", "qtype":1,"rcode":0,"rcode_name":"NOERROR","AA":false,"TC":false,"RD":false,"RA":true,"Z":0,"answers":["ip-172-16-2-16.us-west-2.compute.internal"],"TTLs":[60.0],"rejected":false}`
+	log := `{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","query":"example.com","qtype":2,"qtype_name":"NS","rcode":5,"rcode_name":"REFUSED","answers":["a.iana-servers.net","b.iana-servers.net"]}`
 
-	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233097, time.UTC)
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
 	expectedEvent := &ZeekDNS{
-		TS:        (*timestamp.UnixFloat)(&expectedTime),
-		UID:       aws.String("CpR9AY39cUCZ0t5qq6"),
-		IDOrigH:   aws.String("172.16.2.16"),
-		IDOrigP:   aws.Uint16(43720),
-		IDRespH:   aws.String("172.16.0.2"),
-		IDRespP:   aws.Uint16(53),
-		Proto:     aws.String("udp"),
-		TransID:   aws.Uint16(27282),
-		Query:     aws.String("16.2.16.172.in-addr.arpa"),
-		QType:     aws.Uint64(aQueryType),
-		Rcode:     aws.Uint64(0),
-		RcodeName: aws.String("NOERROR"),
-		AA:        aws.Bool(false),
-		TC:        aws.Bool(false),
-		RD:        aws.Bool(false),
-		RA:        aws.Bool(true),
-		Z:         aws.Int(0),
-		Answers:   []string{"ip-172-16-2-16.us-west-2.compute.internal"},
-		TTLs:      []float64{60.0},
-		Rejected:  aws.Bool(false),
+		TS:          (*Time)(&expectedTime),
+		UID:         aws.String("CpR9AY39cUCZ0t5qq6"),
+		IDOrigH:     aws.String("172.16.2.16"),
+		IDOrigP:     aws.Uint16(43720),
+		IDRespH:     aws.String("172.16.0.2"),
+		IDRespP:     aws.Uint16(53),
+		Proto:       aws.String("udp"),
+		CommunityID: aws.String("1:6V3+ZMlJEyu3Eir1iRtLAUmn7n0="),
+		Query:       aws.String("example.com"),
+		QType:       aws.Uint64(2),
+		QTypeName:   aws.String("NS"),
+		Rcode:       aws.Uint64(5),
+		RcodeName:   aws.String("REFUSED"),
+		Answers:     []string{"a.iana-servers.net", "b.iana-servers.net"},
+		Direction:   aws.String("internal"),
 	}
 
 	// panther fields
@@ -62,11 +136,340 @@ func TestZeekDNS(t *testing.T) {
 	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDOrigH)
 	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDRespH)
 	expectedEvent.AppendAnyDomainNamePtrs(expectedEvent.Query)
-	expectedEvent.AppendAnyDomainNames(expectedEvent.Answers[0])
+	expectedEvent.AppendAnyDomainNames(expectedEvent.Answers...)
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekDNS(t, log, expectedEvent)
+}
+
+func TestZeekDNSQueryDomain(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","query":"mail.example.com.","qtype":15,"qtype_name":"MX","answers":["mx1.example.com."]}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekDNS{
+		TS:          (*Time)(&expectedTime),
+		UID:         aws.String("CpR9AY39cUCZ0t5qq6"),
+		IDOrigH:     aws.String("172.16.2.16"),
+		IDOrigP:     aws.Uint16(43720),
+		IDRespH:     aws.String("172.16.0.2"),
+		IDRespP:     aws.Uint16(53),
+		Proto:       aws.String("udp"),
+		CommunityID: aws.String("1:6V3+ZMlJEyu3Eir1iRtLAUmn7n0="),
+		Query:       aws.String("mail.example.com."),
+		QType:       aws.Uint64(15),
+		QTypeName:   aws.String("MX"),
+		Answers:     []string{"mx1.example.com."},
+		Direction:   aws.String("internal"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.DNS")
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDOrigH)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDRespH)
+	expectedEvent.AppendAnyDomainNames("mail.example.com", "mx1.example.com")
 	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
 	checkZeekDNS(t, log, expectedEvent)
 }
 
+func TestZeekDNSDomainNameCase(t *testing.T) {
+	for query, expected := range map[string]string{
+		"Example.COM.":           "example.com",
+		"example.com":            "example.com",
+		"xn--Bcher-kva.example.": "xn--bcher-kva.example",
+		"xn--bcher-kva.example":  "xn--bcher-kva.example",
+	} {
+		// nolint:lll
+		log := `{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","query":"` + query + `"}`
+		logs, err := (&ZeekDNSParser{}).Parse(log)
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		// The event keeps the query as logged
+		require.Equal(t, query, *logs[0].Event().(*ZeekDNS).Query)
+		expectedIndicators := ZeekPantherLog{}
+		expectedIndicators.AppendAnyDomainNames(expected)
+		require.Equal(t, expectedIndicators.PantherAnyDomainNames, logs[0].PantherAnyDomainNames, query)
+	}
+}
+
+func TestZeekDNSUnsetQuery(t *testing.T) {
+	for _, query := range []string{`""`, `"-"`} {
+		// nolint:lll
+		log := `{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","query":` + query + `}`
+		logs, err := (&ZeekDNSParser{}).Parse(log)
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		require.Nil(t, logs[0].PantherAnyDomainNames, query)
+	}
+}
+
+func TestZeekDNSCodeNames(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","query":"www.example.com","qtype":28,"rcode":3}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekDNS{
+		TS:          (*Time)(&expectedTime),
+		UID:         aws.String("CpR9AY39cUCZ0t5qq6"),
+		IDOrigH:     aws.String("172.16.2.16"),
+		IDOrigP:     aws.Uint16(43720),
+		IDRespH:     aws.String("172.16.0.2"),
+		IDRespP:     aws.Uint16(53),
+		Proto:       aws.String("udp"),
+		CommunityID: aws.String("1:6V3+ZMlJEyu3Eir1iRtLAUmn7n0="),
+		Query:       aws.String("www.example.com"),
+		QType:       aws.Uint64(28),
+		QTypeName:   aws.String("AAAA"),
+		Rcode:       aws.Uint64(3),
+		RcodeName:   aws.String("NXDOMAIN"),
+		Direction:   aws.String("internal"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.DNS")
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDOrigH)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDRespH)
+	expectedEvent.AppendAnyDomainNamePtrs(expectedEvent.Query)
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekDNS(t, log, expectedEvent)
+}
+
+func TestZeekDNSCodeNamesPresent(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","qtype":28,"qtype_name":"custom","rcode":3,"rcode_name":"custom","qclass":1}`
+	logs, err := (&ZeekDNSParser{}).Parse(log)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	event := logs[0].Event().(*ZeekDNS)
+	require.Equal(t, "custom", *event.QTypeName)
+	require.Equal(t, "custom", *event.RcodeName)
+}
+
+func TestZeekDNSMeta(t *testing.T) {
+	// nolint:lll
+	log := `{"_path":"dns","_write_ts":1541001600.612345,"_system_name":"sensor-01","ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedWriteTime := time.Date(2018, 10, 31, 16, 0, 0, 612345000, time.UTC)
+	expectedEvent := &ZeekDNS{
+		TS:          (*Time)(&expectedTime),
+		UID:         aws.String("CpR9AY39cUCZ0t5qq6"),
+		IDOrigH:     aws.String("172.16.2.16"),
+		IDOrigP:     aws.Uint16(43720),
+		IDRespH:     aws.String("172.16.0.2"),
+		IDRespP:     aws.Uint16(53),
+		Proto:       aws.String("udp"),
+		CommunityID: aws.String("1:6V3+ZMlJEyu3Eir1iRtLAUmn7n0="),
+		Direction:   aws.String("internal"),
+		ZeekMeta: ZeekMeta{
+			Path:       aws.String("dns"),
+			WriteTS:    (*Time)(&expectedWriteTime),
+			SystemName: aws.String("sensor-01"),
+		},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.DNS")
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDOrigH)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDRespH)
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekDNS(t, log, expectedEvent)
+
+	// Metadata fields are part of the schema
+	_, err := (&ZeekDNSParser{Strict: true}).Parse(log)
+	require.NoError(t, err)
+}
+
+func TestZeekDNSEventTime(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1591367999.123456,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp"}`
+	logs, err := (&ZeekDNSParser{}).Parse(log)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	eventTime := time.Time(*logs[0].PantherEventTime)
+	require.Equal(t, time.Date(2020, 6, 5, 14, 39, 59, 123456000, time.UTC), eventTime)
+	require.Equal(t, 123456, eventTime.Nanosecond()/int(time.Microsecond))
+}
+
+func TestZeekDNSInvalidTime(t *testing.T) {
+	for _, ts := range []string{`-1591367999.123456`, `1591367999123456`} {
+		// nolint:lll
+		log := `{"ts":` + ts + `,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp"}`
+		_, err := (&ZeekDNSParser{}).Parse(log)
+		require.Error(t, err, ts)
+	}
+}
+
+func TestZeekDNSTSV(t *testing.T) {
+	// nolint:lll
+	logs := strings.Join([]string{
+		`#separator \x09`,
+		"#set_separator\t,",
+		"#empty_field\t(empty)",
+		"#unset_field\t-",
+		"#path\tdns",
+		"#open\t2018-10-31-16-00-00",
+		"#fields\tts\tuid\tid.orig_h\tid.orig_p\tid.resp_h\tid.resp_p\tproto\ttrans_id\trtt\tquery\tqclass\tqclass_name\tqtype\tqtype_name\trcode\trcode_name\tAA\tTC\tRD\tRA\tZ\tanswers\tTTLs\trejected",
+		"#types\ttime\tstring\taddr\tport\taddr\tport\tenum\tcount\tinterval\tstring\tcount\tstring\tcount\tstring\tcount\tstring\tbool\tbool\tbool\tbool\tcount\tvector[string]\tvector[interval]\tbool",
+		"1541001600.580233\tCpR9AY39cUCZ0t5qq6\t172.16.2.16\t43720\t172.16.0.2\t53\tudp\t27282\t-\t16.2.16.172.in-addr.arpa\t1\tC_INTERNET\t1\tA\t0\tNOERROR\tF\tF\tF\tT\t0\tip-172-16-2-16.us-west-2.compute.internal\t60.000000\tF",
+		"1541001600.580233\tCpR9AY39cUCZ0t5qq7\t172.16.2.16\t43721\t172.16.0.2\t53\tudp\t27283\t-\t(empty)\t-\t-\t-\t-\t-\t-\tF\tF\tF\tF\t0\t(empty)\t(empty)\tF",
+		"#close\t2018-10-31-17-00-00",
+	}, "\n")
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekDNS{
+		TS:          (*Time)(&expectedTime),
+		UID:         aws.String("CpR9AY39cUCZ0t5qq6"),
+		IDOrigH:     aws.String("172.16.2.16"),
+		IDOrigP:     aws.Uint16(43720),
+		IDRespH:     aws.String("172.16.0.2"),
+		IDRespP:     aws.Uint16(53),
+		Proto:       aws.String("udp"),
+		CommunityID: aws.String("1:6V3+ZMlJEyu3Eir1iRtLAUmn7n0="),
+		TransID:     aws.Uint16(27282),
+		Query:       aws.String("16.2.16.172.in-addr.arpa"),
+		QClass:      aws.Uint64(1),
+		QClassName:  aws.String("C_INTERNET"),
+		QType:       aws.Uint64(1),
+		QTypeName:   aws.String("A"),
+		Rcode:       aws.Uint64(0),
+		RcodeName:   aws.String("NOERROR"),
+		AA:          aws.Bool(false),
+		TC:          aws.Bool(false),
+		RD:          aws.Bool(false),
+		RA:          aws.Bool(true),
+		Z:           aws.Int(0),
+		Answers:     []string{"ip-172-16-2-16.us-west-2.compute.internal"},
+		TTLs:        []float64{60.0},
+		Rejected:    aws.Bool(false),
+		Direction:   aws.String("internal"),
+	}
+	expectedEvent.SetCoreFields(TypeZeekDNS, (*timestamp.RFC3339)(&expectedTime), expectedEvent)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDOrigH)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDRespH)
+	expectedEvent.AppendAnyDomainNamePtrs(expectedEvent.Query)
+	expectedEvent.AppendAnyDomainNames(expectedEvent.Answers...)
+
+	expectedEmpty := &ZeekDNS{
+		TS:          (*Time)(&expectedTime),
+		UID:         aws.String("CpR9AY39cUCZ0t5qq7"),
+		IDOrigH:     aws.String("172.16.2.16"),
+		IDOrigP:     aws.Uint16(43721),
+		IDRespH:     aws.String("172.16.0.2"),
+		IDRespP:     aws.Uint16(53),
+		Proto:       aws.String("udp"),
+		CommunityID: aws.String("1:5gs3socmc92noCHuayiEo8cygcE="),
+		TransID:     aws.Uint16(27283),
+		Query:       aws.String(""),
+		AA:          aws.Bool(false),
+		TC:          aws.Bool(false),
+		RD:          aws.Bool(false),
+		RA:          aws.Bool(false),
+		Z:           aws.Int(0),
+		Answers:     []string{},
+		TTLs:        []float64{},
+		Rejected:    aws.Bool(false),
+		Direction:   aws.String("internal"),
+	}
+	expectedEmpty.SetCoreFields(TypeZeekDNS, (*timestamp.RFC3339)(&expectedTime), expectedEmpty)
+	expectedEmpty.AppendAnyIPAddressPtr(expectedEmpty.IDOrigH)
+	expectedEmpty.AppendAnyIPAddressPtr(expectedEmpty.IDRespH)
+
+	testutil.CheckPantherMultiline(t, logs, &ZeekDNSParser{}, expectedEvent.Log(), expectedEmpty.Log())
+}
+
+func TestZeekDNSStrict(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","custom_field":"foo","other_field":1}`
+
+	logs, err := (&ZeekDNSParser{}).Parse(log)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+
+	parser := (&ZeekDNSParser{Strict: true}).New()
+	_, err = parser.Parse(log)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "custom_field")
+	require.Contains(t, err.Error(), "other_field")
+
+	// nolint:lll
+	_, err = parser.Parse(`{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp"}`)
+	require.NoError(t, err)
+}
+
+func TestZeekDNSStrictTSV(t *testing.T) {
+	parser := (&ZeekDNSParser{Strict: true}).New()
+	for _, line := range []string{
+		`#separator \x09`,
+		"#fields\tts\tuid\tid.orig_h\tid.orig_p\tid.resp_h\tid.resp_p\tproto\tcustom_field",
+	} {
+		_, err := parser.Parse(line)
+		require.NoError(t, err)
+	}
+	_, err := parser.Parse("1541001600.580233\tCpR9AY39cUCZ0t5qq6\t172.16.2.16\t43720\t172.16.0.2\t53\tudp\tfoo")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "custom_field")
+}
+
+func TestZeekDNSLegacyFieldNames(t *testing.T) {
+	// nolint:lll
+	const fields = `"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","query":"example.com"`
+	current := `{` + fields + `,"AA":true,"TC":false,"RD":true,"RA":true,"Z":1}`
+	legacy := `{` + fields + `,"aa":true,"tc":false,"rd":true,"ra":true,"z":1}`
+
+	parser := (&ZeekDNSParser{Strict: true}).New()
+	expect, err := parser.Parse(current)
+	require.NoError(t, err)
+	require.Len(t, expect, 1)
+	event := expect[0].Event().(*ZeekDNS)
+	require.Equal(t, aws.Bool(true), event.AA)
+	require.Equal(t, aws.Bool(false), event.TC)
+	require.Equal(t, aws.Int(1), event.Z)
+
+	actual, err := parser.Parse(legacy)
+	testutil.EqualPantherLog(t, expect[0], actual, err)
+
+	tsv := (&ZeekDNSParser{Strict: true}).New()
+	for _, line := range []string{
+		`#separator \x09`,
+		"#fields\tts\tuid\tid.orig_h\tid.orig_p\tid.resp_h\tid.resp_p\tproto\tquery\taa\ttc\trd\tra\tz",
+	} {
+		_, err := tsv.Parse(line)
+		require.NoError(t, err)
+	}
+	actual, err = tsv.Parse("1541001600.580233\tCpR9AY39cUCZ0t5qq6\t172.16.2.16\t43720\t172.16.0.2\t53\tudp\texample.com\tT\tF\tT\tT\t1")
+	testutil.EqualPantherLog(t, expect[0], actual, err)
+}
+
+func TestZeekDNSMilliseconds(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","rtt":0.0123,"query":"www.example.com"}`
+
+	logs, err := (&ZeekDNSParser{Milliseconds: true}).New().Parse(log)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	event := logs[0].Event().(*ZeekDNS)
+	require.Equal(t, aws.Float64(0.0123), event.RTT)
+	require.Equal(t, aws.Float64(12.3), event.RTTMillis)
+
+	logs, err = (&ZeekDNSParser{}).New().Parse(log)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	event = logs[0].Event().(*ZeekDNS)
+	require.Equal(t, aws.Float64(0.0123), event.RTT)
+	require.Nil(t, event.RTTMillis)
+	data, err := jsoniter.Marshal(event)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "rtt_ms")
+}
+
+func TestZeekDNSTSVPathMismatch(t *testing.T) {
+	parser := (&ZeekDNSParser{}).New()
+	_, err := parser.Parse(`#separator \x09`)
+	require.NoError(t, err)
+	_, err = parser.Parse("#path\tconn")
+	require.Error(t, err)
+}
+
 func TestZeekDNSType(t *testing.T) {
 	parser := &ZeekDNSParser{}
 	require.Equal(t, "Zeek.DNS", parser.LogType())
@@ -78,3 +481,184 @@ func checkZeekDNS(t *testing.T, log string, expectedEvent *ZeekDNS) {
 	logs, err := parser.Parse(log)
 	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
 }
+
+// nolint:lll
+const benchmarkDNSLog = `{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","trans_id":27282,"query":"www.example.com","qtype":1,"qtype_name":"A","rcode":0,"rcode_name":"NOERROR","answers":["www.example.com.cdn.net","93.184.216.34"],"TTLs":[300.0,60.0]}`
+
+func BenchmarkZeekDNSParser(b *testing.B) {
+	parser := (&ZeekDNSParser{}).New()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := parser.Parse(benchmarkDNSLog); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestZeekDNSResultsOutliveParse(t *testing.T) {
+	parser := (&ZeekDNSParser{}).New()
+	first, err := parser.Parse(benchmarkDNSLog)
+	require.NoError(t, err)
+	expected := *first[0].Event().(*ZeekDNS)
+	_, err = parser.Parse(strings.Replace(benchmarkDNSLog, "www.example.com", "www.example.org", -1))
+	require.NoError(t, err)
+	actual := first[0].Event().(*ZeekDNS)
+	require.Equal(t, "www.example.com", *actual.Query)
+	require.Equal(t, expected, *actual)
+}
+
+func TestZeekDNSParserConcurrent(t *testing.T) {
+	const numGoroutines = 32
+	const numLines = 100
+	errs := make(chan error, numGoroutines)
+	for i := 0; i < numGoroutines; i++ {
+		query := fmt.Sprintf("host-%d.example.com", i)
+		log := strings.Replace(benchmarkDNSLog, "www.example.com", query, -1)
+		go func() {
+			parser := (&ZeekDNSParser{}).New()
+			var results []*parsers.PantherLog
+			for j := 0; j < numLines; j++ {
+				logs, err := parser.Parse(log)
+				if err != nil {
+					errs <- err
+					return
+				}
+				results = append(results, logs...)
+			}
+			for _, result := range results {
+				if actual := *result.Event().(*ZeekDNS).Query; actual != query {
+					errs <- fmt.Errorf("invalid query %q, expected %q", actual, query)
+					return
+				}
+			}
+			errs <- nil
+		}()
+	}
+	for i := 0; i < numGoroutines; i++ {
+		require.NoError(t, <-errs)
+	}
+}
+
+func TestZeekDNSIndicatorPolicy(t *testing.T) {
+	parser := &ZeekDNSParser{
+		Indicators: IndicatorPolicy{
+			Disabled: pantherlog.FieldSet{pantherlog.FieldDomainName},
+		},
+	}
+	require.False(t, parser.Indicators.Enabled(pantherlog.FieldDomainName))
+	require.True(t, parser.Indicators.Enabled(pantherlog.FieldIPAddress))
+	logs, err := parser.New().Parse(benchmarkDNSLog)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	require.Nil(t, logs[0].PantherAnyDomainNames)
+	require.NotNil(t, logs[0].PantherAnyIPAddresses)
+}
+
+func TestZeekDNSIndicatorPolicyDisableAll(t *testing.T) {
+	parser := &ZeekDNSParser{
+		Indicators: IndicatorPolicy{
+			Disabled: pantherlog.DefaultIndicators(),
+		},
+	}
+	logs, err := parser.Parse(benchmarkDNSLog)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	require.Nil(t, logs[0].PantherAnyDomainNames)
+	require.Nil(t, logs[0].PantherAnyIPAddresses)
+	require.Equal(t, "Zeek.DNS", *logs[0].PantherLogType)
+	require.NotNil(t, logs[0].PantherEventTime)
+}
+
+func TestZeekDNSTXTAnswerWithCommas(t *testing.T) {
+	const txt = `TXT 44 "v=spf1 include:_spf.example.com, ~all" "a,b"`
+	// nolint:lll
+	jsonLog := `{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","query":"example.com","qtype":16,"answers":["TXT 44 \"v=spf1 include:_spf.example.com, ~all\" \"a,b\"","93.184.216.34"],"TTLs":[300.0,60.0]}`
+	logs, err := (&ZeekDNSParser{}).Parse(jsonLog)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	fromJSON := logs[0].Event().(*ZeekDNS)
+	require.Equal(t, StringArray{txt, "93.184.216.34"}, fromJSON.Answers)
+
+	// Zeek escapes the set separator inside the elements of TSV sets
+	parser := (&ZeekDNSParser{}).New()
+	for _, line := range []string{
+		`#separator \x09`,
+		"#set_separator\t,",
+		"#path\tdns",
+		"#fields\tts\tuid\tid.orig_h\tid.orig_p\tid.resp_h\tid.resp_p\tproto\tquery\tqtype\tanswers\tTTLs",
+		"#types\ttime\tstring\taddr\tport\taddr\tport\tenum\tstring\tcount\tvector[string]\tvector[interval]",
+	} {
+		_, err := parser.Parse(line)
+		require.NoError(t, err)
+	}
+	// nolint:lll
+	tsvLog := "1541001600.580233\tCpR9AY39cUCZ0t5qq6\t172.16.2.16\t43720\t172.16.0.2\t53\tudp\texample.com\t16\t" + `TXT 44 "v=spf1 include:_spf.example.com\x2c ~all" "a\x2cb",93.184.216.34` + "\t300.0,60.0"
+	logs, err = parser.Parse(tsvLog)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	fromTSV := logs[0].Event().(*ZeekDNS)
+	require.Equal(t, fromJSON.Answers, fromTSV.Answers)
+	require.Equal(t, fromJSON.TTLs, fromTSV.TTLs)
+
+	// A JSON string is never split on commas
+	// nolint:lll
+	stringLog := `{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","query":"example.com","qtype":16,"answers":"TXT 44 \"v=spf1 include:_spf.example.com, ~all\" \"a,b\""}`
+	logs, err = (&ZeekDNSParser{}).Parse(stringLog)
+	require.NoError(t, err)
+	require.Equal(t, StringArray{txt}, logs[0].Event().(*ZeekDNS).Answers)
+}
+
+func TestZeekDNSMappedIPv6(t *testing.T) {
+	log := strings.Replace(benchmarkDNSLog, `"172.16.2.16"`, `"::ffff:172.16.2.16"`, 1)
+	log = strings.Replace(log, `"172.16.0.2"`, `"2001:0db8:0000:0000:0000:0000:0000:0001"`, 1)
+	log = strings.Replace(log, `"93.184.216.34"`, `"::ffff:93.184.216.34"`, 1)
+	logs, err := (&ZeekDNSParser{}).Parse(log)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	event := logs[0].Event().(*ZeekDNS)
+	// Only the indicators are canonical, the fields keep the values logged by Zeek
+	require.Equal(t, "::ffff:172.16.2.16", *event.IDOrigH)
+	require.Equal(t, "2001:0db8:0000:0000:0000:0000:0000:0001", *event.IDRespH)
+	require.Equal(t, StringArray{"www.example.com.cdn.net", "::ffff:93.184.216.34"}, event.Answers)
+
+	expect := &ZeekPantherLog{}
+	expect.AppendAnyIPAddress("172.16.2.16")
+	expect.AppendAnyIPAddress("2001:db8::1")
+	expect.AppendAnyIPAddress("93.184.216.34")
+	require.Equal(t, expect.PantherAnyIPAddresses, logs[0].PantherAnyIPAddresses)
+}
+
+func TestZeekDNSInvalidIPAddress(t *testing.T) {
+	observer := &invalidFieldObserver{
+		countingObserver: countingObserver{
+			parsed: map[string]int{},
+			failed: map[string]int{},
+		},
+	}
+	SetObserver(observer)
+	defer SetObserver(nil)
+
+	log := strings.Replace(benchmarkDNSLog, `"172.16.2.16"`, `"172.16.2.300"`, 1)
+	logs, err := (&ZeekDNSParser{}).Parse(log)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	require.Equal(t, "172.16.2.300", *logs[0].Event().(*ZeekDNS).IDOrigH)
+
+	expect := &ZeekPantherLog{}
+	expect.AppendAnyIPAddress("172.16.0.2")
+	expect.AppendAnyIPAddress("93.184.216.34")
+	require.Equal(t, expect.PantherAnyIPAddresses, logs[0].PantherAnyIPAddresses)
+	require.Equal(t, []string{"Zeek.DNS id.orig_h=172.16.2.300"}, observer.invalid)
+
+	// Invalid addresses are not reported if IP address indicators are disabled
+	parser := &ZeekDNSParser{
+		Indicators: IndicatorPolicy{
+			Disabled: pantherlog.FieldSet{pantherlog.FieldIPAddress},
+		},
+	}
+	logs, err = parser.Parse(log)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	require.Empty(t, logs[0].PantherAnyIPAddresses)
+	require.Len(t, observer.invalid, 1)
+}
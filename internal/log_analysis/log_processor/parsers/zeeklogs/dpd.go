@@ -0,0 +1,107 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekDPD struct {
+	TS            *Time   `json:"ts,omitempty" validate:"required" description:"Timestamp for when protocol analysis failed."`
+	UID           *string `json:"uid,omitempty" description:"A unique identifier of the connection."`
+	IDOrigH       *string `json:"id.orig_h,omitempty" panther:"ip" description:"The originator’s IP address."`
+	IDOrigP       *uint16 `json:"id.orig_p,omitempty" description:"The originator’s port number."`
+	IDRespH       *string `json:"id.resp_h,omitempty" panther:"ip" description:"The responder’s IP address."`
+	IDRespP       *uint16 `json:"id.resp_p,omitempty" description:"The responder’s port number."`
+	Proto         *string `json:"proto,omitempty" description:"Transport protocol for the violation."`
+	Analyzer      *string `json:"analyzer,omitempty" description:"The analyzer that generated the violation."`
+	FailureReason *string `json:"failure_reason,omitempty" description:"The textual reason for the analysis failure."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekDPDParser parses zeek dpd logs
+type ZeekDPDParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekDPDParser)(nil)
+
+func (p *ZeekDPDParser) New() parsers.LogParser {
+	return &ZeekDPDParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekDPDParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekDPD := &ZeekDPD{}
+
+	ok, err := p.decoder.Decode(log, pathDPD, zeekDPD, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekDPD.updatePantherFields(p)
+	p.Indicators.apply(&zeekDPD.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekDPD); err != nil {
+		return nil, err
+	}
+
+	return zeekDPD.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekDPDParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekDPDParser) LogType() string {
+	return TypeZeekDPD
+}
+
+func (event *ZeekDPD) updatePantherFields(p *ZeekDPDParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+}
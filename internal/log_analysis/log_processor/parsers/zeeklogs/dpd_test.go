@@ -0,0 +1,83 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekDPD(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CVrYO53jWaOUFbRBb3","id.orig_h":"192.168.4.76","id.orig_p":50906,"id.resp_h":"198.51.100.40","id.resp_p":80,"proto":"tcp","analyzer":"HTTP","failure_reason":"not a http request line"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekDPD{
+		TS:            (*Time)(&expectedTime),
+		UID:           aws.String("CVrYO53jWaOUFbRBb3"),
+		IDOrigH:       aws.String("192.168.4.76"),
+		IDOrigP:       aws.Uint16(50906),
+		IDRespH:       aws.String("198.51.100.40"),
+		IDRespP:       aws.Uint16(80),
+		Proto:         aws.String("tcp"),
+		Analyzer:      aws.String("HTTP"),
+		FailureReason: aws.String("not a http request line"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.DPD")
+	expectedEvent.AppendAnyIPAddress("192.168.4.76")
+	expectedEvent.AppendAnyIPAddress("198.51.100.40")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekDPD(t, log, expectedEvent)
+}
+
+func TestZeekDPDSparse(t *testing.T) {
+	log := `{"ts":1541001600.580233,"analyzer":"SSL","failure_reason":"Invalid version late in TLS connection. Packet reported version: 0"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekDPD{
+		TS:            (*Time)(&expectedTime),
+		Analyzer:      aws.String("SSL"),
+		FailureReason: aws.String("Invalid version late in TLS connection. Packet reported version: 0"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.DPD")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekDPD(t, log, expectedEvent)
+}
+
+func TestZeekDPDType(t *testing.T) {
+	parser := &ZeekDPDParser{}
+	require.Equal(t, "Zeek.DPD", parser.LogType())
+}
+
+func checkZeekDPD(t *testing.T, log string, expectedEvent *ZeekDPD) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekDPDParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
@@ -0,0 +1,145 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekFiles struct {
+	TS              *Time    `json:"ts,omitempty" validate:"required" description:"The time when the file was first seen."`
+	FUID            *string  `json:"fuid,omitempty" validate:"required" description:"An identifier associated with a single file."`
+	TxHosts         Set      `json:"tx_hosts,omitempty" panther:"ip" description:"If this file was transferred over a network connection this should show the host or hosts that the data sourced from."`
+	RxHosts         Set      `json:"rx_hosts,omitempty" panther:"ip" description:"If this file was transferred over a network connection this should show the host or hosts that the data traveled to."`
+	ConnUIDs        Set      `json:"conn_uids,omitempty" description:"Connection UIDs over which the file was transferred."`
+	Source          *string  `json:"source,omitempty" description:"An identification of the source of the file data. E.g. it may be a network protocol over which it was transferred, or a local file path which was read, or some other input source."`
+	Depth           *uint64  `json:"depth,omitempty" description:"A value to represent the depth of this file in relation to its source. In SMTP, it is the depth of the MIME attachment on the message. In HTTP, it is the depth of the request within the TCP connection."`
+	Analyzers       Set      `json:"analyzers,omitempty" description:"A set of analysis types done during the file analysis."`
+	MIMEType        *string  `json:"mime_type,omitempty" description:"A mime type provided by the strongest file magic signature match against the bof_buffer field of fa_file, or in the cases where no buffering of the beginning of file occurs, an initial guess of the mime type based on the first data seen."`
+	Filename        *string  `json:"filename,omitempty" description:"A filename for the file if one is available from the source for the file. These will frequently come from “Content-Disposition” headers in network protocols."`
+	Duration        *float64 `json:"duration,omitempty" description:"The duration the file was analyzed for."`
+	DurationMillis  *float64 `json:"duration_ms,omitempty" description:"The duration the file was analyzed for in milliseconds, only set if the parser has Milliseconds enabled."`
+	LocalOrig       *bool    `json:"local_orig,omitempty" description:"If the source of this file is a network connection, this field indicates if the data originated from the local network or not as determined by the configured Site::local_nets."`
+	IsOrig          *bool    `json:"is_orig,omitempty" description:"If the source of this file is a network connection, this field indicates if the file is being sent by the originator of the connection or the responder."`
+	SeenBytes       *uint64  `json:"seen_bytes,omitempty" description:"Number of bytes provided to the file analysis engine for the file."`
+	TotalBytes      *uint64  `json:"total_bytes,omitempty" description:"Total number of bytes that are supposed to comprise the full file."`
+	MissingBytes    *uint64  `json:"missing_bytes,omitempty" description:"The number of bytes in the file stream that were completely missed during the process of analysis e.g. due to dropped packets."`
+	OverflowBytes   *uint64  `json:"overflow_bytes,omitempty" description:"The number of bytes in the file stream that were not delivered to stream file analyzers. This could be overlapping bytes or bytes that couldn’t be reassembled."`
+	TimedOut        *bool    `json:"timedout,omitempty" description:"Whether the file analysis timed out at least once for the file."`
+	ParentFUID      *string  `json:"parent_fuid,omitempty" description:"Identifier associated with a container file from which this one was extracted as part of the file analysis."`
+	MD5             *string  `json:"md5,omitempty" panther:"md5" description:"An MD5 digest of the file contents."`
+	SHA1            *string  `json:"sha1,omitempty" panther:"sha1" description:"A SHA1 digest of the file contents."`
+	SHA256          *string  `json:"sha256,omitempty" panther:"sha256" description:"A SHA256 digest of the file contents."`
+	Extracted       *string  `json:"extracted,omitempty" description:"Local filename of extracted file."`
+	ExtractedCutoff *bool    `json:"extracted_cutoff,omitempty" description:"Set to true if the file being extracted was cut off so the whole file was not logged."`
+	ExtractedSize   *uint64  `json:"extracted_size,omitempty" description:"The number of bytes extracted to disk."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekFilesParser parses zeek files logs
+type ZeekFilesParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	// Milliseconds adds a field with the value in milliseconds next to each duration field in seconds
+	Milliseconds bool
+	decoder      logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekFilesParser)(nil)
+
+func (p *ZeekFilesParser) New() parsers.LogParser {
+	return &ZeekFilesParser{
+		Strict:       p.Strict,
+		TimeOffset:   p.TimeOffset,
+		MinTime:      p.MinTime,
+		MaxTime:      p.MaxTime,
+		Indicators:   p.Indicators,
+		Milliseconds: p.Milliseconds,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekFilesParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekFiles := &ZeekFiles{}
+
+	ok, err := p.decoder.Decode(log, pathFiles, zeekFiles, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	if p.Milliseconds {
+		zeekFiles.DurationMillis = secondsToMillis(zeekFiles.Duration)
+	}
+	zeekFiles.updatePantherFields(p)
+	p.Indicators.apply(&zeekFiles.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekFiles); err != nil {
+		return nil, err
+	}
+
+	return zeekFiles.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekFilesParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekFilesParser) LogType() string {
+	return TypeZeekFiles
+}
+
+func (event *ZeekFiles) updatePantherFields(p *ZeekFilesParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	for i := range event.TxHosts {
+		appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "tx_hosts", &event.TxHosts[i])
+	}
+	for i := range event.RxHosts {
+		appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "rx_hosts", &event.RxHosts[i])
+	}
+
+	// Hashes are only computed for some files and unset hashes should not become indicators
+	if isSetPtr(event.MD5) {
+		event.AppendAnyMD5Hashes(*event.MD5)
+	}
+	if isSetPtr(event.SHA1) {
+		event.AppendAnySHA1Hashes(*event.SHA1)
+	}
+	if isSetPtr(event.SHA256) {
+		event.AppendAnySHA256Hashes(*event.SHA256)
+	}
+}
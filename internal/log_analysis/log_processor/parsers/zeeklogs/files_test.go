@@ -0,0 +1,126 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekFiles(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"fuid":"FjY1Lu2RmCY6Ct1wZe","tx_hosts":["93.184.216.34"],"rx_hosts":["172.16.2.16"],"conn_uids":["CwFs1P2UcUdlSxD2La"],"source":"HTTP","depth":0,"analyzers":["MD5","SHA1","SHA256"],"mime_type":"application/x-dosexec","filename":"setup.exe","duration":0.001234,"local_orig":false,"is_orig":false,"seen_bytes":73802,"total_bytes":73802,"missing_bytes":0,"overflow_bytes":0,"timedout":false,"md5":"8a3d6a5c4a6f0d3f5e2e1e6d9e6f1c2b","sha1":"2fd4e1c67a2d28fced849ee1bb76e7391b93eb12","sha256":"d7a8fbb307d7809469ca9abcb0082e4f8d5651e46d3cdb762d02d0bf37c9e592"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekFiles{
+		TS:            (*Time)(&expectedTime),
+		FUID:          aws.String("FjY1Lu2RmCY6Ct1wZe"),
+		TxHosts:       []string{"93.184.216.34"},
+		RxHosts:       []string{"172.16.2.16"},
+		ConnUIDs:      []string{"CwFs1P2UcUdlSxD2La"},
+		Source:        aws.String("HTTP"),
+		Depth:         aws.Uint64(0),
+		Analyzers:     []string{"MD5", "SHA1", "SHA256"},
+		MIMEType:      aws.String("application/x-dosexec"),
+		Filename:      aws.String("setup.exe"),
+		Duration:      aws.Float64(0.001234),
+		LocalOrig:     aws.Bool(false),
+		IsOrig:        aws.Bool(false),
+		SeenBytes:     aws.Uint64(73802),
+		TotalBytes:    aws.Uint64(73802),
+		MissingBytes:  aws.Uint64(0),
+		OverflowBytes: aws.Uint64(0),
+		TimedOut:      aws.Bool(false),
+		MD5:           aws.String("8a3d6a5c4a6f0d3f5e2e1e6d9e6f1c2b"),
+		SHA1:          aws.String("2fd4e1c67a2d28fced849ee1bb76e7391b93eb12"),
+		SHA256:        aws.String("d7a8fbb307d7809469ca9abcb0082e4f8d5651e46d3cdb762d02d0bf37c9e592"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Files")
+	expectedEvent.AppendAnyIPAddress("93.184.216.34")
+	expectedEvent.AppendAnyIPAddress("172.16.2.16")
+	expectedEvent.AppendAnyMD5Hashes("8a3d6a5c4a6f0d3f5e2e1e6d9e6f1c2b")
+	expectedEvent.AppendAnySHA1Hashes("2fd4e1c67a2d28fced849ee1bb76e7391b93eb12")
+	expectedEvent.AppendAnySHA256Hashes("d7a8fbb307d7809469ca9abcb0082e4f8d5651e46d3cdb762d02d0bf37c9e592")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekFiles(t, log, expectedEvent)
+}
+
+func TestZeekFilesUnsetHashes(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"fuid":"FjY1Lu2RmCY6Ct1wZe","tx_hosts":["93.184.216.34"],"rx_hosts":["172.16.2.16"],"conn_uids":["CwFs1P2UcUdlSxD2La"],"source":"HTTP","mime_type":"text/plain","md5":"-","sha1":"-"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekFiles{
+		TS:       (*Time)(&expectedTime),
+		FUID:     aws.String("FjY1Lu2RmCY6Ct1wZe"),
+		TxHosts:  []string{"93.184.216.34"},
+		RxHosts:  []string{"172.16.2.16"},
+		ConnUIDs: []string{"CwFs1P2UcUdlSxD2La"},
+		Source:   aws.String("HTTP"),
+		MIMEType: aws.String("text/plain"),
+		MD5:      aws.String("-"),
+		SHA1:     aws.String("-"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Files")
+	expectedEvent.AppendAnyIPAddress("93.184.216.34")
+	expectedEvent.AppendAnyIPAddress("172.16.2.16")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekFiles(t, log, expectedEvent)
+}
+
+func TestZeekFilesMappedIPv6(t *testing.T) {
+	// Indicators are canonical while the fields keep the addresses logged by Zeek
+	checkZeekFixture(t, zeekFixture{
+		LogType: TypeZeekFiles,
+		// nolint:lll
+		Log: `{"ts":1541001600.580233,"fuid":"FjY1Lu2RmCY6Ct1wZe","tx_hosts":["::ffff:93.184.216.34"],"rx_hosts":["172.16.2.16","2001:0db8:0000:0000:0000:0000:0000:0001"],"source":"HTTP"}`,
+		Expect: `{
+			"ts": "2018-10-31 16:00:00.580233000",
+			"fuid": "FjY1Lu2RmCY6Ct1wZe",
+			"tx_hosts": ["::ffff:93.184.216.34"],
+			"rx_hosts": ["172.16.2.16", "2001:0db8:0000:0000:0000:0000:0000:0001"],
+			"source": "HTTP",
+			"p_log_type": "Zeek.Files",
+			"p_event_time": "2018-10-31 16:00:00.580233000",
+			"p_any_ip_addresses": ["172.16.2.16", "2001:db8::1", "93.184.216.34"]
+		}`,
+	})
+}
+
+func TestZeekFilesType(t *testing.T) {
+	parser := &ZeekFilesParser{}
+	require.Equal(t, "Zeek.Files", parser.LogType())
+}
+
+func checkZeekFiles(t *testing.T, log string, expectedEvent *ZeekFiles) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekFilesParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
@@ -0,0 +1,126 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekFTP struct {
+	TS                 *Time   `json:"ts,omitempty" validate:"required" description:"Time when the command was sent."`
+	UID                *string `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection."`
+	IDOrigH            *string `json:"id.orig_h" panther:"ip" validate:"required" description:"The originator’s IP address."`
+	IDOrigP            *uint16 `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
+	IDRespH            *string `json:"id.resp_h" panther:"ip" validate:"required" description:"The responder’s IP address."`
+	IDRespP            *uint16 `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
+	User               *string `json:"user,omitempty" panther:"username" description:"User name for the current FTP session."`
+	Password           *string `json:"password,omitempty" description:"Password for the current FTP session if captured."`
+	Command            *string `json:"command,omitempty" description:"Command given by the client."`
+	Arg                *string `json:"arg,omitempty" description:"Argument for the command if one is given."`
+	MIMEType           *string `json:"mime_type,omitempty" description:"Sniffed mime type of file."`
+	FileSize           *uint64 `json:"file_size,omitempty" description:"Size of the file if the command indicates a file transfer."`
+	ReplyCode          *uint64 `json:"reply_code,omitempty" description:"Reply code from the server in response to the command."`
+	ReplyMsg           *string `json:"reply_msg,omitempty" description:"Reply message from the server in response to the command."`
+	DataChannelPassive *bool   `json:"data_channel.passive,omitempty" description:"Whether PASV mode is toggled for the data channel."`
+	DataChannelOrigH   *string `json:"data_channel.orig_h,omitempty" panther:"ip" description:"The host that will be initiating the data connection."`
+	DataChannelRespH   *string `json:"data_channel.resp_h,omitempty" panther:"ip" description:"The host that will be accepting the data connection."`
+	DataChannelRespP   *uint16 `json:"data_channel.resp_p,omitempty" description:"The port at which the acceptor is listening for the data connection."`
+	FUID               *string `json:"fuid,omitempty" description:"File unique ID."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ftpUnknownUser is the user Zeek logs for commands sent before the USER command
+const ftpUnknownUser = "<unknown>"
+
+// ZeekFTPParser parses zeek ftp logs
+type ZeekFTPParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekFTPParser)(nil)
+
+func (p *ZeekFTPParser) New() parsers.LogParser {
+	return &ZeekFTPParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekFTPParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekFTP := &ZeekFTP{}
+
+	ok, err := p.decoder.Decode(log, pathFTP, zeekFTP, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekFTP.updatePantherFields(p)
+	p.Indicators.apply(&zeekFTP.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekFTP); err != nil {
+		return nil, err
+	}
+
+	return zeekFTP.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekFTPParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekFTPParser) LogType() string {
+	return TypeZeekFTP
+}
+
+func (event *ZeekFTP) updatePantherFields(p *ZeekFTPParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "data_channel.orig_h", event.DataChannelOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "data_channel.resp_h", event.DataChannelRespH)
+
+	if isSetPtr(event.User) && *event.User != ftpUnknownUser {
+		event.AppendAnyUsernamePtrs(event.User)
+	}
+}
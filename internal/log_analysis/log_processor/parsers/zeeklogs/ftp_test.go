@@ -0,0 +1,104 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekFTP(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CNY1Pu2Rs6QiBPjUse","id.orig_h":"10.0.0.5","id.orig_p":49464,"id.resp_h":"192.0.2.21","id.resp_p":21,"user":"jdoe","password":"<hidden>","command":"RETR","arg":"ftp://192.0.2.21/./secret.zip","mime_type":"application/zip","file_size":10485760,"reply_code":150,"reply_msg":"Opening BINARY mode data connection","data_channel.passive":true,"data_channel.orig_h":"10.0.0.5","data_channel.resp_h":"192.0.2.22","data_channel.resp_p":30012,"fuid":"FgnlVv1mlYxPkpLQ7j"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekFTP{
+		TS:                 (*Time)(&expectedTime),
+		UID:                aws.String("CNY1Pu2Rs6QiBPjUse"),
+		IDOrigH:            aws.String("10.0.0.5"),
+		IDOrigP:            aws.Uint16(49464),
+		IDRespH:            aws.String("192.0.2.21"),
+		IDRespP:            aws.Uint16(21),
+		User:               aws.String("jdoe"),
+		Password:           aws.String("<hidden>"),
+		Command:            aws.String("RETR"),
+		Arg:                aws.String("ftp://192.0.2.21/./secret.zip"),
+		MIMEType:           aws.String("application/zip"),
+		FileSize:           aws.Uint64(10485760),
+		ReplyCode:          aws.Uint64(150),
+		ReplyMsg:           aws.String("Opening BINARY mode data connection"),
+		DataChannelPassive: aws.Bool(true),
+		DataChannelOrigH:   aws.String("10.0.0.5"),
+		DataChannelRespH:   aws.String("192.0.2.22"),
+		DataChannelRespP:   aws.Uint16(30012),
+		FUID:               aws.String("FgnlVv1mlYxPkpLQ7j"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.FTP")
+	expectedEvent.AppendAnyIPAddress("10.0.0.5")
+	expectedEvent.AppendAnyIPAddress("192.0.2.21")
+	expectedEvent.AppendAnyIPAddress("192.0.2.22")
+	expectedEvent.AppendAnyUsernames("jdoe")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekFTP(t, log, expectedEvent)
+}
+
+func TestZeekFTPUnknownUser(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CNY1Pu2Rs6QiBPjUse","id.orig_h":"10.0.0.5","id.orig_p":49464,"id.resp_h":"192.0.2.21","id.resp_p":21,"user":"<unknown>","command":"SYST","reply_msg":"UNIX Type: L8"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekFTP{
+		TS:       (*Time)(&expectedTime),
+		UID:      aws.String("CNY1Pu2Rs6QiBPjUse"),
+		IDOrigH:  aws.String("10.0.0.5"),
+		IDOrigP:  aws.Uint16(49464),
+		IDRespH:  aws.String("192.0.2.21"),
+		IDRespP:  aws.Uint16(21),
+		User:     aws.String("<unknown>"),
+		Command:  aws.String("SYST"),
+		ReplyMsg: aws.String("UNIX Type: L8"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.FTP")
+	expectedEvent.AppendAnyIPAddress("10.0.0.5")
+	expectedEvent.AppendAnyIPAddress("192.0.2.21")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekFTP(t, log, expectedEvent)
+}
+
+func TestZeekFTPType(t *testing.T) {
+	parser := &ZeekFTPParser{}
+	require.Equal(t, "Zeek.FTP", parser.LogType())
+}
+
+func checkZeekFTP(t *testing.T, log string, expectedEvent *ZeekFTP) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekFTPParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
@@ -0,0 +1,95 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files of the zeek parser tests")
+
+// zeekFixture is a test case for the parser of a registered Zeek log type
+type zeekFixture struct {
+	LogType string
+	// Log is the input log line
+	Log string
+	// Expect is the JSON of the parsed event including the panther indicator fields.
+	// The row id and parse time change on each run so they are only checked to be set.
+	Expect string
+	// Golden is the name of a file in testdata the JSON of the parsed event is compared to, if set.
+	// Running the tests with -update rewrites the file with the current output of the parser.
+	Golden string
+}
+
+// checkZeekFixture checks that the parser of a Zeek log type produces the expected event for a log line.
+// The golden file catches changes to the schema that alter the output of a parser without updating its tests.
+func checkZeekFixture(t *testing.T, fixture zeekFixture) {
+	t.Helper()
+	entry := logtypes.DefaultRegistry().Get(fixture.LogType)
+	require.NotNil(t, entry, "log type %q not registered", fixture.LogType)
+	parser, err := entry.NewParser(nil)
+	require.NoError(t, err)
+	results, err := parser.ParseLog(fixture.Log)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	data, err := jsoniter.Marshal(results[0])
+	require.NoError(t, err)
+	actual := goldenJSON(t, data)
+	require.JSONEq(t, fixture.Expect, string(actual))
+
+	if fixture.Golden == "" {
+		return
+	}
+	path := filepath.Join("testdata", fixture.Golden+".golden.json")
+	if *updateGolden {
+		require.NoError(t, ioutil.WriteFile(path, actual, 0600))
+		return
+	}
+	expect, err := ioutil.ReadFile(path)
+	require.NoError(t, err, "run the tests with -update to create the golden file")
+	require.Equal(t, string(expect), string(actual), "output does not match %s", path)
+}
+
+// goldenJSON formats the JSON of an event with sorted keys and without the panther fields that change on each run.
+// It fails if the row id or the parse time are not set.
+func goldenJSON(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var event map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	// Keep numbers as is so that large integers are not rounded
+	dec.UseNumber()
+	require.NoError(t, dec.Decode(&event))
+	for _, field := range []string{"p_row_id", "p_parse_time"} {
+		require.NotEmpty(t, event[field], field)
+		delete(event, field)
+	}
+	out, err := json.MarshalIndent(event, "", "  ")
+	require.NoError(t, err)
+	return append(out, '\n')
+}
@@ -0,0 +1,193 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekHTTP struct {
+	TS              *Time   `json:"ts,omitempty" validate:"required" description:"Timestamp for when the request happened."`
+	UID             *string `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection."`
+	IDOrigH         *string `json:"id.orig_h" panther:"ip" validate:"required" description:"The originator’s IP address."`
+	IDOrigP         *uint16 `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
+	IDRespH         *string `json:"id.resp_h" panther:"ip" validate:"required" description:"The responder’s IP address."`
+	IDRespP         *uint16 `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
+	TransDepth      *uint64 `json:"trans_depth,omitempty" description:"Represents the pipelined depth into the connection of this request/response transaction."`
+	Method          *string `json:"method,omitempty" description:"Verb used in the HTTP request (GET, POST, HEAD, etc.)."`
+	Host            *string `json:"host,omitempty" panther:"hostname" description:"Value of the HOST header."`
+	URI             *string `json:"uri,omitempty" panther:"url" description:"URI used in the request."`
+	Referrer        *string `json:"referrer,omitempty" description:"Value of the Referer header."`
+	Version         *string `json:"version,omitempty" description:"Value of the version portion of the request."`
+	UserAgent       *string `json:"user_agent,omitempty" description:"Value of the User-Agent header from the client."`
+	Origin          *string `json:"origin,omitempty" description:"Value of the Origin header from the client."`
+	RequestBodyLen  *uint64 `json:"request_body_len,omitempty" description:"Actual uncompressed content size of the data transferred from the client."`
+	ResponseBodyLen *uint64 `json:"response_body_len,omitempty" description:"Actual uncompressed content size of the data transferred from the server."`
+	StatusCode      *uint64 `json:"status_code,omitempty" description:"Status code returned by the server."`
+	StatusMsg       *string `json:"status_msg,omitempty" description:"Status message returned by the server."`
+	InfoCode        *uint64 `json:"info_code,omitempty" description:"Last seen 1xx informational reply code returned by the server."`
+	InfoMsg         *string `json:"info_msg,omitempty" description:"Last seen 1xx informational reply message returned by the server."`
+	Tags            Set     `json:"tags,omitempty" description:"A set of indicators of various attributes discovered and related to a particular request/response pair."`
+	Username        *string `json:"username,omitempty" description:"Username if basic-auth is performed for the request."`
+	Password        *string `json:"password,omitempty" description:"Password if basic-auth is performed for the request."`
+	Proxied         Set     `json:"proxied,omitempty" description:"All of the headers that may indicate if the request was proxied."`
+	OrigFUIDs       Set     `json:"orig_fuids,omitempty" description:"An ordered vector of file unique IDs from the originator."`
+	OrigFilenames   Set     `json:"orig_filenames,omitempty" description:"An ordered vector of filenames from the client."`
+	OrigMIMETypes   Set     `json:"orig_mime_types,omitempty" description:"An ordered vector of mime types from the originator."`
+	RespFUIDs       Set     `json:"resp_fuids,omitempty" description:"An ordered vector of file unique IDs from the responder."`
+	RespFilenames   Set     `json:"resp_filenames,omitempty" description:"An ordered vector of filenames from the server."`
+	RespMIMETypes   Set     `json:"resp_mime_types,omitempty" description:"An ordered vector of mime types from the responder."`
+	Direction       *string `json:"direction,omitempty" description:"Direction of the connection relative to the local networks of the parser (inbound, outbound, internal or external)."`
+	URL             *string `json:"url,omitempty" panther:"url" description:"The full URL of the request reconstructed from the host and uri fields."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekHTTPParser parses zeek http logs
+type ZeekHTTPParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	// LocalNetworks are the internal networks used to set the direction of connections, RFC 1918 ranges by default
+	LocalNetworks Networks
+	decoder       logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekHTTPParser)(nil)
+
+func (p *ZeekHTTPParser) New() parsers.LogParser {
+	return &ZeekHTTPParser{
+		Strict:        p.Strict,
+		TimeOffset:    p.TimeOffset,
+		MinTime:       p.MinTime,
+		MaxTime:       p.MaxTime,
+		Indicators:    p.Indicators,
+		LocalNetworks: p.LocalNetworks,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekHTTPParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekHTTP := &ZeekHTTP{}
+
+	ok, err := p.decoder.Decode(log, pathHTTP, zeekHTTP, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekHTTP.Direction = connDirection(p.LocalNetworks, zeekHTTP.IDOrigH, zeekHTTP.IDRespH)
+	zeekHTTP.setURL()
+	zeekHTTP.updatePantherFields(p)
+	p.Indicators.apply(&zeekHTTP.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekHTTP); err != nil {
+		return nil, err
+	}
+
+	return zeekHTTP.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekHTTPParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekHTTPParser) LogType() string {
+	return TypeZeekHTTP
+}
+
+// setURL reconstructs the full URL of the request unless it was logged by Zeek.
+// The scheme is https for requests to port 443 and http otherwise.
+// Requests without a host header use the responder address as the host and requests through a proxy log the full
+// URL (or the `host:port` authority of CONNECT requests) as the uri.
+func (event *ZeekHTTP) setURL() {
+	if event.URL != nil || !isSetPtr(event.URI) {
+		return
+	}
+	uri := *event.URI
+	if strings.Contains(uri, "://") {
+		event.URL = &uri
+		return
+	}
+	if event.Method != nil && strings.EqualFold(*event.Method, "CONNECT") {
+		u := httpScheme(uri) + "://" + uri
+		event.URL = &u
+		return
+	}
+	var host string
+	switch {
+	case isSetPtr(event.Host):
+		host = *event.Host
+	case isSetPtr(event.IDRespH) && event.IDRespP != nil:
+		host = *event.IDRespH
+		if port := *event.IDRespP; port != 80 && port != 443 {
+			host = net.JoinHostPort(host, strconv.Itoa(int(port)))
+		} else if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+	default:
+		return
+	}
+	if !strings.HasPrefix(uri, "/") {
+		uri = "/" + uri
+	}
+	scheme := "http"
+	if event.IDRespP != nil && *event.IDRespP == 443 {
+		scheme = "https"
+	}
+	u := scheme + "://" + host + uri
+	event.URL = &u
+}
+
+// httpScheme returns the scheme for the `host:port` authority of a CONNECT request
+func httpScheme(authority string) string {
+	if _, port, err := net.SplitHostPort(authority); err == nil && port == "443" {
+		return "https"
+	}
+	return "http"
+}
+
+func (event *ZeekHTTP) updatePantherFields(p *ZeekHTTPParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+	appendHostnamePtr(&event.ZeekPantherLog, event.Host)
+	if event.URL != nil {
+		appendURL(&event.ZeekPantherLog, *event.URL)
+	}
+}
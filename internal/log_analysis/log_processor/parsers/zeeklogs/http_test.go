@@ -0,0 +1,223 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekHTTP(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CwFs1P2UcUdlSxD2La","id.orig_h":"172.16.2.16","id.orig_p":49826,"id.resp_h":"93.184.216.34","id.resp_p":80,"trans_depth":1,"method":"GET","host":"www.example.com","uri":"/index.html?q=1","referrer":"http://www.example.com/","version":"1.1","user_agent":"curl/7.61.1","request_body_len":0,"response_body_len":1256,"status_code":200,"status_msg":"OK","tags":[],"resp_fuids":["FjY1Lu2RmCY6Ct1wZe"],"resp_mime_types":["text/html"]}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekHTTP{
+		TS:              (*Time)(&expectedTime),
+		UID:             aws.String("CwFs1P2UcUdlSxD2La"),
+		IDOrigH:         aws.String("172.16.2.16"),
+		IDOrigP:         aws.Uint16(49826),
+		IDRespH:         aws.String("93.184.216.34"),
+		IDRespP:         aws.Uint16(80),
+		TransDepth:      aws.Uint64(1),
+		Method:          aws.String("GET"),
+		Host:            aws.String("www.example.com"),
+		URI:             aws.String("/index.html?q=1"),
+		Referrer:        aws.String("http://www.example.com/"),
+		Version:         aws.String("1.1"),
+		UserAgent:       aws.String("curl/7.61.1"),
+		RequestBodyLen:  aws.Uint64(0),
+		ResponseBodyLen: aws.Uint64(1256),
+		StatusCode:      aws.Uint64(200),
+		StatusMsg:       aws.String("OK"),
+		Tags:            []string{},
+		RespFUIDs:       []string{"FjY1Lu2RmCY6Ct1wZe"},
+		RespMIMETypes:   []string{"text/html"},
+		Direction:       aws.String("outbound"),
+		URL:             aws.String("http://www.example.com/index.html?q=1"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.HTTP")
+	expectedEvent.AppendAnyURLPtrs(expectedEvent.URL)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDOrigH)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDRespH)
+	expectedEvent.AppendAnyDomainNamePtrs(expectedEvent.Host)
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekHTTP(t, log, expectedEvent)
+}
+
+func TestZeekHTTPHostWithPort(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CwFs1P2UcUdlSxD2La","id.orig_h":"172.16.2.16","id.orig_p":49826,"id.resp_h":"10.0.0.1","id.resp_p":8080,"trans_depth":1,"method":"POST","host":"10.0.0.1:8080","uri":"/upload","request_body_len":5120,"response_body_len":0,"status_code":201,"orig_fuids":["FjY1Lu2RmCY6Ct1wZe","Fz7lbq1QXRBjTmKBq3"],"orig_mime_types":["application/zip","application/zip"]}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekHTTP{
+		TS:              (*Time)(&expectedTime),
+		UID:             aws.String("CwFs1P2UcUdlSxD2La"),
+		IDOrigH:         aws.String("172.16.2.16"),
+		IDOrigP:         aws.Uint16(49826),
+		IDRespH:         aws.String("10.0.0.1"),
+		IDRespP:         aws.Uint16(8080),
+		TransDepth:      aws.Uint64(1),
+		Method:          aws.String("POST"),
+		Host:            aws.String("10.0.0.1:8080"),
+		URI:             aws.String("/upload"),
+		RequestBodyLen:  aws.Uint64(5120),
+		ResponseBodyLen: aws.Uint64(0),
+		StatusCode:      aws.Uint64(201),
+		OrigFUIDs:       []string{"FjY1Lu2RmCY6Ct1wZe", "Fz7lbq1QXRBjTmKBq3"},
+		OrigMIMETypes:   []string{"application/zip", "application/zip"},
+		Direction:       aws.String("internal"),
+		URL:             aws.String("http://10.0.0.1:8080/upload"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.HTTP")
+	expectedEvent.AppendAnyURLPtrs(expectedEvent.URL)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDOrigH)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDRespH)
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekHTTP(t, log, expectedEvent)
+}
+
+func TestZeekHTTPSURL(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CwFs1P2UcUdlSxD2La","id.orig_h":"172.16.2.16","id.orig_p":49826,"id.resp_h":"93.184.216.34","id.resp_p":443,"trans_depth":1,"method":"GET","host":"www.example.com","uri":"/login"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekHTTP{
+		TS:         (*Time)(&expectedTime),
+		UID:        aws.String("CwFs1P2UcUdlSxD2La"),
+		IDOrigH:    aws.String("172.16.2.16"),
+		IDOrigP:    aws.Uint16(49826),
+		IDRespH:    aws.String("93.184.216.34"),
+		IDRespP:    aws.Uint16(443),
+		TransDepth: aws.Uint64(1),
+		Method:     aws.String("GET"),
+		Host:       aws.String("www.example.com"),
+		URI:        aws.String("/login"),
+		Direction:  aws.String("outbound"),
+		URL:        aws.String("https://www.example.com/login"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.HTTP")
+	expectedEvent.AppendAnyURLPtrs(expectedEvent.URL)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDOrigH)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDRespH)
+	expectedEvent.AppendAnyDomainNamePtrs(expectedEvent.Host)
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekHTTP(t, log, expectedEvent)
+}
+
+func TestZeekHTTPURLWithoutHost(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CwFs1P2UcUdlSxD2La","id.orig_h":"172.16.2.16","id.orig_p":49826,"id.resp_h":"93.184.216.34","id.resp_p":80,"trans_depth":1,"method":"GET","uri":"/index.html","version":"1.0"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekHTTP{
+		TS:         (*Time)(&expectedTime),
+		UID:        aws.String("CwFs1P2UcUdlSxD2La"),
+		IDOrigH:    aws.String("172.16.2.16"),
+		IDOrigP:    aws.Uint16(49826),
+		IDRespH:    aws.String("93.184.216.34"),
+		IDRespP:    aws.Uint16(80),
+		TransDepth: aws.Uint64(1),
+		Method:     aws.String("GET"),
+		URI:        aws.String("/index.html"),
+		Version:    aws.String("1.0"),
+		Direction:  aws.String("outbound"),
+		URL:        aws.String("http://93.184.216.34/index.html"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.HTTP")
+	expectedEvent.AppendAnyURLPtrs(expectedEvent.URL)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDOrigH)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDRespH)
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekHTTP(t, log, expectedEvent)
+}
+
+func TestZeekHTTPURL(t *testing.T) {
+	for _, tc := range []struct {
+		Fields string
+		URL    string
+		Domain string
+	}{
+		{`"id.resp_h":"2001:db8::1","id.resp_p":443,"uri":"/"`, "https://[2001:db8::1]/", ""},
+		{`"id.resp_h":"2001:db8::1","id.resp_p":8443,"uri":"/"`, "http://[2001:db8::1]:8443/", ""},
+		{`"id.resp_h":"10.0.0.8","id.resp_p":3128,"method":"GET","host":"proxy.local","uri":"http://www.example.com/a.exe"`, "http://www.example.com/a.exe", "www.example.com"},
+		{`"id.resp_h":"10.0.0.8","id.resp_p":3128,"method":"CONNECT","uri":"www.example.com:443"`, "https://www.example.com:443", "www.example.com"},
+		{`"id.resp_h":"10.0.0.8","id.resp_p":80,"host":"www.example.com","uri":"-"`, "", ""},
+	} {
+		// nolint:lll
+		log := `{"ts":1541001600.580233,"uid":"CwFs1P2UcUdlSxD2La","id.orig_h":"172.16.2.16","id.orig_p":49826,` + tc.Fields + `}`
+		logs, err := (&ZeekHTTPParser{}).Parse(log)
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		event := logs[0].Event().(*ZeekHTTP)
+		if tc.URL == "" {
+			require.Nil(t, event.URL, tc.Fields)
+			continue
+		}
+		require.Equal(t, tc.URL, aws.StringValue(event.URL), tc.Fields)
+		// The URL is added to the indicators along with its host
+		urls, err := json.Marshal(event.PantherAnyURLs)
+		require.NoError(t, err)
+		require.Contains(t, string(urls), `"`+tc.URL+`"`, tc.Fields)
+		if tc.Domain != "" {
+			domains, err := json.Marshal(event.PantherAnyDomainNames)
+			require.NoError(t, err)
+			require.Contains(t, string(domains), `"`+tc.Domain+`"`, tc.Fields)
+		}
+	}
+}
+
+func TestZeekHTTPLargeURI(t *testing.T) {
+	uri := "/search?q=" + strings.Repeat("A", 1<<20)
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CwFs1P2UcUdlSxD2La","id.orig_h":"172.16.2.16","id.orig_p":49826,"id.resp_h":"93.184.216.34","id.resp_p":80,"method":"GET","host":"www.example.com","uri":"` + uri + `"}`
+	logs, err := (&ZeekHTTPParser{}).Parse(log)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	event := logs[0].Event().(*ZeekHTTP)
+	require.Equal(t, uri, *event.URI)
+}
+
+func TestZeekHTTPType(t *testing.T) {
+	parser := &ZeekHTTPParser{}
+	require.Equal(t, "Zeek.HTTP", parser.LogType())
+}
+
+func checkZeekHTTP(t *testing.T, log string, expectedEvent *ZeekHTTP) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekHTTPParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
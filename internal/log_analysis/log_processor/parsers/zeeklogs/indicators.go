@@ -0,0 +1,236 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net"
+	"net/mail"
+	"net/url"
+	"strings"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// IndicatorPolicy selects the indicator fields a parser adds to events.
+// The zero value enables all indicators.
+//
+// Indicator fields are never required so disabling all of them still produces valid events,
+// they will only have the core panther fields (`p_log_type`, `p_event_time` etc).
+type IndicatorPolicy struct {
+	// Disabled lists the indicator fields that are not added to events (e.g. pantherlog.FieldDomainName)
+	Disabled pantherlog.FieldSet
+}
+
+// Enabled checks if an indicator field is added to events
+func (p *IndicatorPolicy) Enabled(id pantherlog.FieldID) bool {
+	for _, disabled := range p.Disabled {
+		if disabled == id {
+			return false
+		}
+	}
+	return true
+}
+
+// apply removes the values of disabled indicator fields from a log
+func (p *IndicatorPolicy) apply(pl *ZeekPantherLog) {
+	for _, id := range p.Disabled {
+		switch id {
+		case pantherlog.FieldIPAddress:
+			pl.PantherAnyIPAddresses = nil
+		case pantherlog.FieldDomainName:
+			pl.PantherAnyDomainNames = nil
+		case pantherlog.FieldMD5Hash:
+			pl.PantherAnyMD5Hashes = nil
+		case pantherlog.FieldSHA1Hash:
+			pl.PantherAnySHA1Hashes = nil
+		case pantherlog.FieldSHA256Hash:
+			pl.PantherAnySHA256Hashes = nil
+		case pantherlog.FieldUsername:
+			pl.PantherAnyUsernames = nil
+		case pantherlog.FieldEmail:
+			pl.PantherAnyEmails = nil
+		case pantherlog.FieldMACAddress:
+			pl.PantherAnyMACAddresses = nil
+		case pantherlog.FieldURL:
+			pl.PantherAnyURLs = nil
+		}
+	}
+}
+
+// appendIPAddressField validates the value of an IP address field and appends it to the indicators of a log.
+// The indicator is the canonical form of the address (IPv4-mapped IPv6 addresses become plain IPv4 addresses and
+// IPv6 addresses are compressed) so that the same address always matches the same indicator value.
+// The field keeps the value logged by Zeek.
+// Invalid addresses are reported to the registered FieldObserver instead of being silently dropped,
+// unless IP address indicators are disabled by the policy of the parser.
+// Nil, empty and unset (`-`) values are ignored.
+func appendIPAddressField(pl *ZeekPantherLog, indicators *IndicatorPolicy, field string, addr *string) {
+	if !isSetPtr(addr) || !indicators.Enabled(pantherlog.FieldIPAddress) {
+		return
+	}
+	canonical, ok := canonicalIPAddress(*addr)
+	if !ok {
+		logType := ""
+		if pl.PantherLogType != nil {
+			logType = *pl.PantherLogType
+		}
+		observeInvalidField(logType, field, *addr)
+		return
+	}
+	pl.AppendAnyIPAddress(canonical)
+}
+
+// canonicalIPAddress returns the canonical text form of an IP address
+func canonicalIPAddress(addr string) (string, bool) {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return "", false
+	}
+	// net.IP.String formats IPv4-mapped IPv6 addresses as IPv4
+	return ip.String(), true
+}
+
+// appendDomainName appends a domain name to the indicators of a log in the form returned by normalizeDomainName.
+// Empty and unset (`-`) values are ignored.
+func appendDomainName(pl *ZeekPantherLog, name string) {
+	name = normalizeDomainName(name)
+	if !isSet(name) {
+		return
+	}
+	pl.AppendAnyDomainNames(name)
+}
+
+// normalizeDomainName lowercases a domain name and removes the trailing dot of fully qualified names
+// so that `Example.COM.` and `example.com` match.
+// Internationalized names are not converted between their Unicode and punycode (`xn--`) forms.
+func normalizeDomainName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// appendDomainNamePtr appends a domain name to the indicators of a log if it is not nil.
+func appendDomainNamePtr(pl *ZeekPantherLog, name *string) {
+	if name != nil {
+		appendDomainName(pl, *name)
+	}
+}
+
+// appendHostname appends a host to the indicators of a log as either an IP address or a domain name.
+// Hosts can include a port (i.e. from an HTTP `Host` header) which is removed.
+func appendHostname(pl *ZeekPantherLog, host string) {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if canonical, ok := canonicalIPAddress(host); ok {
+		pl.AppendAnyIPAddress(canonical)
+		return
+	}
+	appendDomainName(pl, host)
+}
+
+// appendHostnamePtr appends a host to the indicators of a log if it is not nil.
+func appendHostnamePtr(pl *ZeekPantherLog, host *string) {
+	if host != nil {
+		appendHostname(pl, *host)
+	}
+}
+
+// appendURL appends a URL to the indicators of a log along with its host as either an IP address or a domain name.
+// The URL indicator is the value as logged.
+// Zeek logs URLs without a scheme (i.e. `example.com/index.html`) so one is assumed to find the host if it is missing.
+func appendURL(pl *ZeekPantherLog, rawURL string) {
+	if !isSet(rawURL) {
+		return
+	}
+	pl.AppendAnyURLs(rawURL)
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "http://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return
+	}
+	if host := u.Hostname(); host != "" {
+		appendHostname(pl, host)
+	}
+}
+
+// appendMACAddress appends a MAC address to the indicators of a log in the form returned by normalizeMAC.
+// Values that are not valid MAC addresses are ignored.
+func appendMACAddress(pl *ZeekPantherLog, mac string) {
+	if mac, ok := pantherlog.NormalizeMACAddress(mac); ok {
+		pl.AppendAnyMACAddresses(mac)
+	}
+}
+
+// appendMACAddressPtr appends a MAC address to the indicators of a log if it is not nil.
+func appendMACAddressPtr(pl *ZeekPantherLog, mac *string) {
+	if mac != nil {
+		appendMACAddress(pl, *mac)
+	}
+}
+
+// normalizeMAC formats a MAC address with pantherlog.NormalizeMACAddress.
+// Values that are not valid MAC addresses are returned as-is.
+func normalizeMAC(mac string) string {
+	if normalized, ok := pantherlog.NormalizeMACAddress(mac); ok {
+		return normalized
+	}
+	return mac
+}
+
+// appendEmails appends the email addresses of an address header value to the indicators of a log.
+// Display names are removed from addresses in the `Name <user@example.com>` form.
+func appendEmails(pl *ZeekPantherLog, value string) {
+	if !isSet(value) {
+		return
+	}
+	if addresses, err := mail.ParseAddressList(value); err == nil {
+		for _, addr := range addresses {
+			pl.AppendAnyEmails(addr.Address)
+		}
+		return
+	}
+	// Fallback for values that do not conform to RFC 5322
+	if start := strings.LastIndexByte(value, '<'); start != -1 {
+		if end := strings.IndexByte(value[start:], '>'); end != -1 {
+			value = value[start+1 : start+end]
+		}
+	}
+	value = strings.TrimSpace(value)
+	if strings.Count(value, "@") == 1 && !strings.ContainsAny(value, " <>") {
+		pl.AppendAnyEmails(value)
+	}
+}
+
+// appendEmailsPtr appends the email addresses of an address header value to the indicators of a log if it is not nil.
+func appendEmailsPtr(pl *ZeekPantherLog, value *string) {
+	if value != nil {
+		appendEmails(pl, *value)
+	}
+}
+
+// isSet checks if a value is neither empty nor unset (`-`).
+func isSet(value string) bool {
+	return value != "" && value != tsvDefaultUnsetField
+}
+
+// isSetPtr checks if an optional value is neither nil, empty nor unset (`-`).
+func isSetPtr(value *string) bool {
+	return value != nil && isSet(*value)
+}
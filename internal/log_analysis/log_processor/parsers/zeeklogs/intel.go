@@ -0,0 +1,148 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekIntel struct {
+	TS                *Time   `json:"ts,omitempty" validate:"required" description:"Timestamp when the data was discovered."`
+	UID               *string `json:"uid,omitempty" description:"If a connection was associated with this intelligence hit, this is the uid for the connection."`
+	IDOrigH           *string `json:"id.orig_h,omitempty" panther:"ip" description:"The originator’s IP address."`
+	IDOrigP           *uint16 `json:"id.orig_p,omitempty" description:"The originator’s port number."`
+	IDRespH           *string `json:"id.resp_h,omitempty" panther:"ip" description:"The responder’s IP address."`
+	IDRespP           *uint16 `json:"id.resp_p,omitempty" description:"The responder’s port number."`
+	SeenIndicator     *string `json:"seen.indicator,omitempty" description:"The intelligence indicator."`
+	SeenIndicatorType *string `json:"seen.indicator_type,omitempty" description:"The type of data that the indicator represents (e.g. Intel::ADDR)."`
+	SeenWhere         *string `json:"seen.where,omitempty" description:"Where the data was discovered (e.g. HTTP::IN_HOST_HEADER)."`
+	SeenNode          *string `json:"seen.node,omitempty" description:"The name of the node where the match was discovered."`
+	Matched           Set     `json:"matched,omitempty" description:"Which indicator types matched."`
+	Sources           Set     `json:"sources,omitempty" description:"Sources which supplied data that resulted in this match."`
+	FUID              *string `json:"fuid,omitempty" description:"If a file was associated with this intelligence hit, this is the uid for the file."`
+	FileMIMEType      *string `json:"file_mime_type,omitempty" description:"A mime type if the intelligence hit is related to a file."`
+	FileDesc          *string `json:"file_desc,omitempty" description:"Frequently files can be described to give a bit more context."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekIntelParser parses zeek intel logs
+type ZeekIntelParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekIntelParser)(nil)
+
+func (p *ZeekIntelParser) New() parsers.LogParser {
+	return &ZeekIntelParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekIntelParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekIntel := &ZeekIntel{}
+
+	ok, err := p.decoder.Decode(log, pathIntel, zeekIntel, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekIntel.updatePantherFields(p)
+	p.Indicators.apply(&zeekIntel.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekIntel); err != nil {
+		return nil, err
+	}
+
+	return zeekIntel.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekIntelParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekIntelParser) LogType() string {
+	return TypeZeekIntel
+}
+
+func (event *ZeekIntel) updatePantherFields(p *ZeekIntelParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+	if event.SeenIndicator != nil && event.SeenIndicatorType != nil {
+		appendIntelIndicator(&event.ZeekPantherLog, &p.Indicators, *event.SeenIndicatorType, event.SeenIndicator)
+	}
+}
+
+// appendIntelIndicator appends an intelligence indicator to the panther field that matches its type
+func appendIntelIndicator(pl *ZeekPantherLog, indicators *IndicatorPolicy, indicatorType string, field *string) {
+	if !isSetPtr(field) {
+		return
+	}
+	indicator := *field
+	switch indicatorType {
+	case "Intel::ADDR":
+		appendIPAddressField(pl, indicators, "seen.indicator", field)
+	case "Intel::DOMAIN":
+		appendDomainName(pl, indicator)
+	case "Intel::URL":
+		appendURL(pl, indicator)
+	case "Intel::EMAIL":
+		appendEmails(pl, indicator)
+	case "Intel::USER_NAME":
+		pl.AppendAnyUsernames(indicator)
+	case "Intel::CERT_HASH":
+		pl.AppendAnySHA1Hashes(indicator)
+	case "Intel::FILE_HASH":
+		// The hash algorithm is not logged so it is derived from the length of the hex digest
+		switch len(indicator) {
+		case 32:
+			pl.AppendAnyMD5Hashes(indicator)
+		case 40:
+			pl.AppendAnySHA1Hashes(indicator)
+		case 64:
+			pl.AppendAnySHA256Hashes(indicator)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekIntel(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CCGOE04yvhVhbmISv3","id.orig_h":"192.168.1.2","id.orig_p":52225,"id.resp_h":"198.51.100.7","id.resp_p":443,"seen.indicator":"198.51.100.7","seen.indicator_type":"Intel::ADDR","seen.where":"Conn::IN_RESP","seen.node":"worker-1","matched":["Intel::ADDR"],"sources":["abuse.ch","internal"]}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekIntel{
+		TS:                (*Time)(&expectedTime),
+		UID:               aws.String("CCGOE04yvhVhbmISv3"),
+		IDOrigH:           aws.String("192.168.1.2"),
+		IDOrigP:           aws.Uint16(52225),
+		IDRespH:           aws.String("198.51.100.7"),
+		IDRespP:           aws.Uint16(443),
+		SeenIndicator:     aws.String("198.51.100.7"),
+		SeenIndicatorType: aws.String("Intel::ADDR"),
+		SeenWhere:         aws.String("Conn::IN_RESP"),
+		SeenNode:          aws.String("worker-1"),
+		Matched:           []string{"Intel::ADDR"},
+		Sources:           []string{"abuse.ch", "internal"},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Intel")
+	expectedEvent.AppendAnyIPAddress("192.168.1.2")
+	expectedEvent.AppendAnyIPAddress("198.51.100.7")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekIntel(t, log, expectedEvent)
+}
+
+func TestZeekIntelURL(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CCGOE04yvhVhbmISv3","id.orig_h":"192.168.1.2","id.orig_p":52226,"id.resp_h":"198.51.100.8","id.resp_p":80,"seen.indicator":"malware.example.com/payload.exe","seen.indicator_type":"Intel::URL","seen.where":"HTTP::IN_URL","matched":["Intel::URL"],"sources":["feed"],"fuid":"FJd1ou2ppYSMuoaxRd","file_mime_type":"application/x-dosexec"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekIntel{
+		TS:                (*Time)(&expectedTime),
+		UID:               aws.String("CCGOE04yvhVhbmISv3"),
+		IDOrigH:           aws.String("192.168.1.2"),
+		IDOrigP:           aws.Uint16(52226),
+		IDRespH:           aws.String("198.51.100.8"),
+		IDRespP:           aws.Uint16(80),
+		SeenIndicator:     aws.String("malware.example.com/payload.exe"),
+		SeenIndicatorType: aws.String("Intel::URL"),
+		SeenWhere:         aws.String("HTTP::IN_URL"),
+		Matched:           []string{"Intel::URL"},
+		Sources:           []string{"feed"},
+		FUID:              aws.String("FJd1ou2ppYSMuoaxRd"),
+		FileMIMEType:      aws.String("application/x-dosexec"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Intel")
+	expectedEvent.AppendAnyIPAddress("192.168.1.2")
+	expectedEvent.AppendAnyIPAddress("198.51.100.8")
+	expectedEvent.AppendAnyURLs("malware.example.com/payload.exe")
+	expectedEvent.AppendAnyDomainNames("malware.example.com")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekIntel(t, log, expectedEvent)
+}
+
+func TestAppendIntelIndicator(t *testing.T) {
+	for _, tc := range []struct {
+		Type      string
+		Indicator string
+		Expect    func(pl *ZeekPantherLog)
+	}{
+		{"Intel::DOMAIN", "example.com.", func(pl *ZeekPantherLog) { pl.AppendAnyDomainNames("example.com") }},
+		{"Intel::URL", "https://10.1.1.1:8443/login", func(pl *ZeekPantherLog) {
+			pl.AppendAnyURLs("https://10.1.1.1:8443/login")
+			pl.AppendAnyIPAddress("10.1.1.1")
+		}},
+		{"Intel::EMAIL", "phish@example.com", func(pl *ZeekPantherLog) { pl.AppendAnyEmails("phish@example.com") }},
+		{"Intel::USER_NAME", "admin", func(pl *ZeekPantherLog) { pl.AppendAnyUsernames("admin") }},
+		{"Intel::FILE_HASH", "d41d8cd98f00b204e9800998ecf8427e", func(pl *ZeekPantherLog) {
+			pl.AppendAnyMD5Hashes("d41d8cd98f00b204e9800998ecf8427e")
+		}},
+		{"Intel::FILE_HASH", "da39a3ee5e6b4b0d3255bfef95601890afd80709", func(pl *ZeekPantherLog) {
+			pl.AppendAnySHA1Hashes("da39a3ee5e6b4b0d3255bfef95601890afd80709")
+		}},
+		{"Intel::SOFTWARE", "Mozilla/5.0", func(pl *ZeekPantherLog) {}},
+		{"Intel::ADDR", "-", func(pl *ZeekPantherLog) {}},
+		{"Intel::ADDR", "::ffff:198.51.100.8", func(pl *ZeekPantherLog) { pl.AppendAnyIPAddress("198.51.100.8") }},
+	} {
+		actual, expected := &ZeekPantherLog{}, &ZeekPantherLog{}
+		indicator := tc.Indicator
+		appendIntelIndicator(actual, &IndicatorPolicy{}, tc.Type, &indicator)
+		tc.Expect(expected)
+		require.Equal(t, expected, actual, tc.Type)
+	}
+}
+
+func TestZeekIntelMappedIPv6(t *testing.T) {
+	// Indicators are canonical while the fields keep the addresses logged by Zeek
+	checkZeekFixture(t, zeekFixture{
+		LogType: TypeZeekIntel,
+		// nolint:lll
+		Log: `{"ts":1541001600.580233,"uid":"CCGOE04yvhVhbmISv3","id.orig_h":"192.168.1.2","id.orig_p":52225,"id.resp_h":"::ffff:198.51.100.7","id.resp_p":443,"seen.indicator":"::ffff:198.51.100.7","seen.indicator_type":"Intel::ADDR","seen.where":"Conn::IN_RESP"}`,
+		Expect: `{
+			"ts": "2018-10-31 16:00:00.580233000",
+			"uid": "CCGOE04yvhVhbmISv3",
+			"id.orig_h": "192.168.1.2",
+			"id.orig_p": 52225,
+			"id.resp_h": "::ffff:198.51.100.7",
+			"id.resp_p": 443,
+			"seen.indicator": "::ffff:198.51.100.7",
+			"seen.indicator_type": "Intel::ADDR",
+			"seen.where": "Conn::IN_RESP",
+			"p_log_type": "Zeek.Intel",
+			"p_event_time": "2018-10-31 16:00:00.580233000",
+			"p_any_ip_addresses": ["192.168.1.2", "198.51.100.7"]
+		}`,
+	})
+}
+
+func TestZeekIntelType(t *testing.T) {
+	parser := &ZeekIntelParser{}
+	require.Equal(t, "Zeek.Intel", parser.LogType())
+}
+
+func checkZeekIntel(t *testing.T, log string, expectedEvent *ZeekIntel) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekIntelParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
@@ -0,0 +1,119 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekIRC struct {
+	TS          *Time   `json:"ts,omitempty" validate:"required" description:"Timestamp when the command was seen."`
+	UID         *string `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection."`
+	IDOrigH     *string `json:"id.orig_h" panther:"ip" validate:"required" description:"The originator’s IP address."`
+	IDOrigP     *uint16 `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
+	IDRespH     *string `json:"id.resp_h" panther:"ip" validate:"required" description:"The responder’s IP address."`
+	IDRespP     *uint16 `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
+	Nick        *string `json:"nick,omitempty" panther:"username" description:"Nickname given for the connection."`
+	User        *string `json:"user,omitempty" panther:"username" description:"Username given for the connection."`
+	Command     *string `json:"command,omitempty" description:"Command given by the client."`
+	Value       *string `json:"value,omitempty" description:"Value for the command given by the client."`
+	Addl        *string `json:"addl,omitempty" description:"Any additional data for the command."`
+	DCCFileName *string `json:"dcc_file_name,omitempty" description:"DCC filename requested."`
+	DCCFileSize *uint64 `json:"dcc_file_size,omitempty" description:"Size of the DCC transfer as indicated by the sender."`
+	DCCMIMEType *string `json:"dcc_mime_type,omitempty" description:"Sniffed mime type of the file."`
+	FUID        *string `json:"fuid,omitempty" description:"File unique ID."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekIRCParser parses zeek irc logs
+type ZeekIRCParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekIRCParser)(nil)
+
+func (p *ZeekIRCParser) New() parsers.LogParser {
+	return &ZeekIRCParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekIRCParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekIRC := &ZeekIRC{}
+
+	ok, err := p.decoder.Decode(log, pathIRC, zeekIRC, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekIRC.updatePantherFields(p)
+	p.Indicators.apply(&zeekIRC.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekIRC); err != nil {
+		return nil, err
+	}
+
+	return zeekIRC.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekIRCParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekIRCParser) LogType() string {
+	return TypeZeekIRC
+}
+
+func (event *ZeekIRC) updatePantherFields(p *ZeekIRCParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+
+	for _, name := range []*string{event.Nick, event.User} {
+		if isSetPtr(name) {
+			event.AppendAnyUsernamePtrs(name)
+		}
+	}
+}
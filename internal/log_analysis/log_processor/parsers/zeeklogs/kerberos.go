@@ -0,0 +1,132 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekKerberos struct {
+	TS                *Time   `json:"ts,omitempty" validate:"required" description:"Timestamp for when the event happened."`
+	UID               *string `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection."`
+	IDOrigH           *string `json:"id.orig_h" panther:"ip" validate:"required" description:"The originator’s IP address."`
+	IDOrigP           *uint16 `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
+	IDRespH           *string `json:"id.resp_h" panther:"ip" validate:"required" description:"The responder’s IP address."`
+	IDRespP           *uint16 `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
+	RequestType       *string `json:"request_type,omitempty" description:"Request type - Authentication Service (“AS”) or Ticket Granting Service (“TGS”)."`
+	Client            *string `json:"client,omitempty" panther:"username" description:"Client principal (e.g. user/REALM)."`
+	Service           *string `json:"service,omitempty" description:"Service."`
+	Success           *bool   `json:"success,omitempty" description:"Request result."`
+	ErrorMsg          *string `json:"error_msg,omitempty" description:"Error message."`
+	From              *Time   `json:"from,omitempty" description:"Ticket valid from."`
+	Till              *Time   `json:"till,omitempty" description:"Ticket valid till."`
+	Cipher            *string `json:"cipher,omitempty" description:"Ticket encryption type."`
+	Forwardable       *bool   `json:"forwardable,omitempty" description:"Forwardable ticket requested."`
+	Renewable         *bool   `json:"renewable,omitempty" description:"Renewable ticket requested."`
+	ClientCertSubject *string `json:"client_cert_subject,omitempty" description:"Subject of client certificate, if any."`
+	ClientCertFUID    *string `json:"client_cert_fuid,omitempty" description:"File unique ID of client cert, if any."`
+	ServerCertSubject *string `json:"server_cert_subject,omitempty" description:"Subject of server certificate, if any."`
+	ServerCertFUID    *string `json:"server_cert_fuid,omitempty" description:"File unique ID of server cert, if any."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekKerberosParser parses zeek kerberos logs
+type ZeekKerberosParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekKerberosParser)(nil)
+
+func (p *ZeekKerberosParser) New() parsers.LogParser {
+	return &ZeekKerberosParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekKerberosParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekKerberos := &ZeekKerberos{}
+
+	ok, err := p.decoder.Decode(log, pathKerberos, zeekKerberos, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekKerberos.updatePantherFields(p)
+	p.Indicators.apply(&zeekKerberos.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekKerberos); err != nil {
+		return nil, err
+	}
+
+	return zeekKerberos.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekKerberosParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekKerberosParser) LogType() string {
+	return TypeZeekKerberos
+}
+
+func (event *ZeekKerberos) updatePantherFields(p *ZeekKerberosParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+
+	if isSetPtr(event.Client) {
+		event.AppendAnyUsernames(principalUsername(*event.Client))
+	}
+}
+
+// principalUsername returns the name part of a Kerberos principal logged as `name/REALM`.
+// The name part can contain slashes for service principals (i.e. `host/ws01.example.com/EXAMPLE.COM`).
+func principalUsername(principal string) string {
+	if pos := strings.LastIndexByte(principal, '/'); pos != -1 {
+		return principal[:pos]
+	}
+	return principal
+}
@@ -0,0 +1,108 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekKerberos(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CliUXs2yAGNGqSk4sh","id.orig_h":"10.0.0.21","id.orig_p":49232,"id.resp_h":"10.0.0.5","id.resp_p":88,"request_type":"TGS","client":"jdoe/CONTOSO.LOCAL","service":"cifs/fs01.contoso.local","success":true,"from":1541001600.0,"till":1541037600.0,"cipher":"aes256-cts-hmac-sha1-96","forwardable":true,"renewable":true}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	from := time.Date(2018, 10, 31, 16, 0, 0, 0, time.UTC)
+	till := time.Date(2018, 11, 1, 2, 0, 0, 0, time.UTC)
+	expectedEvent := &ZeekKerberos{
+		TS:          (*Time)(&expectedTime),
+		UID:         aws.String("CliUXs2yAGNGqSk4sh"),
+		IDOrigH:     aws.String("10.0.0.21"),
+		IDOrigP:     aws.Uint16(49232),
+		IDRespH:     aws.String("10.0.0.5"),
+		IDRespP:     aws.Uint16(88),
+		RequestType: aws.String("TGS"),
+		Client:      aws.String("jdoe/CONTOSO.LOCAL"),
+		Service:     aws.String("cifs/fs01.contoso.local"),
+		Success:     aws.Bool(true),
+		From:        (*Time)(&from),
+		Till:        (*Time)(&till),
+		Cipher:      aws.String("aes256-cts-hmac-sha1-96"),
+		Forwardable: aws.Bool(true),
+		Renewable:   aws.Bool(true),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Kerberos")
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDOrigH)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDRespH)
+	expectedEvent.AppendAnyUsernames("jdoe")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekKerberos(t, log, expectedEvent)
+}
+
+func TestZeekKerberosWithoutSuccess(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CliUXs2yAGNGqSk4sh","id.orig_h":"10.0.0.21","id.orig_p":49232,"id.resp_h":"10.0.0.5","id.resp_p":88,"request_type":"AS","client":"WS01$/CONTOSO.LOCAL","service":"krbtgt/CONTOSO.LOCAL"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekKerberos{
+		TS:          (*Time)(&expectedTime),
+		UID:         aws.String("CliUXs2yAGNGqSk4sh"),
+		IDOrigH:     aws.String("10.0.0.21"),
+		IDOrigP:     aws.Uint16(49232),
+		IDRespH:     aws.String("10.0.0.5"),
+		IDRespP:     aws.Uint16(88),
+		RequestType: aws.String("AS"),
+		Client:      aws.String("WS01$/CONTOSO.LOCAL"),
+		Service:     aws.String("krbtgt/CONTOSO.LOCAL"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Kerberos")
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDOrigH)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDRespH)
+	expectedEvent.AppendAnyUsernames("WS01$")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekKerberos(t, log, expectedEvent)
+}
+
+func TestPrincipalUsername(t *testing.T) {
+	require.Equal(t, "jdoe", principalUsername("jdoe/CONTOSO.LOCAL"))
+	require.Equal(t, "host/ws01.contoso.local", principalUsername("host/ws01.contoso.local/CONTOSO.LOCAL"))
+	require.Equal(t, "jdoe", principalUsername("jdoe"))
+}
+
+func TestZeekKerberosType(t *testing.T) {
+	parser := &ZeekKerberosParser{}
+	require.Equal(t, "Zeek.Kerberos", parser.LogType())
+}
+
+func checkZeekKerberos(t *testing.T, log string, expectedEvent *ZeekKerberos) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekKerberosParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
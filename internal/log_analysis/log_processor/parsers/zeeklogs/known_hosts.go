@@ -0,0 +1,99 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekKnownHosts struct {
+	TS   *Time   `json:"ts,omitempty" validate:"required" description:"The timestamp at which the host was detected."`
+	Host *string `json:"host" panther:"ip" validate:"required" description:"The address that was detected originating or responding to a TCP connection."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekKnownHostsParser parses zeek known_hosts logs
+type ZeekKnownHostsParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekKnownHostsParser)(nil)
+
+func (p *ZeekKnownHostsParser) New() parsers.LogParser {
+	return &ZeekKnownHostsParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekKnownHostsParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekKnownHosts := &ZeekKnownHosts{}
+
+	ok, err := p.decoder.Decode(log, pathKnownHosts, zeekKnownHosts, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekKnownHosts.updatePantherFields(p)
+	p.Indicators.apply(&zeekKnownHosts.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekKnownHosts); err != nil {
+		return nil, err
+	}
+
+	return zeekKnownHosts.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekKnownHostsParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekKnownHostsParser) LogType() string {
+	return TypeZeekKnownHosts
+}
+
+func (event *ZeekKnownHosts) updatePantherFields(p *ZeekKnownHostsParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "host", event.Host)
+}
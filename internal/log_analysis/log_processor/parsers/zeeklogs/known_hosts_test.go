@@ -0,0 +1,45 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestZeekKnownHosts(t *testing.T) {
+	checkZeekFixture(t, zeekFixture{
+		LogType: TypeZeekKnownHosts,
+		Log:     `{"ts":1541001600.580233,"host":"10.0.0.25"}`,
+		Expect: `{
+			"ts": "2018-10-31 16:00:00.580233000",
+			"host": "10.0.0.25",
+			"p_log_type": "Zeek.Known_Hosts",
+			"p_event_time": "2018-10-31 16:00:00.580233000",
+			"p_any_ip_addresses": ["10.0.0.25"]
+		}`,
+		Golden: "known_hosts",
+	})
+}
+
+func TestZeekKnownHostsType(t *testing.T) {
+	parser := &ZeekKnownHostsParser{}
+	require.Equal(t, "Zeek.Known_Hosts", parser.LogType())
+}
@@ -0,0 +1,70 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZeekKnownServices(t *testing.T) {
+	checkZeekFixture(t, zeekFixture{
+		LogType: TypeZeekKnownServices,
+		// nolint:lll
+		Log: `{"ts":1541001600.580233,"host":"10.0.0.25","port_num":443,"port_proto":"tcp","service":["SSL","HTTP"]}`,
+		Expect: `{
+			"ts": "2018-10-31 16:00:00.580233000",
+			"host": "10.0.0.25",
+			"port_num": 443,
+			"port_proto": "tcp",
+			"service": ["SSL", "HTTP"],
+			"p_log_type": "Zeek.Known_Services",
+			"p_event_time": "2018-10-31 16:00:00.580233000",
+			"p_any_ip_addresses": ["10.0.0.25"]
+		}`,
+		Golden: "known_services",
+	})
+}
+
+func TestZeekKnownServicesTSV(t *testing.T) {
+	parser := (&ZeekKnownServicesParser{}).New()
+	for _, line := range []string{
+		`#separator \x09`,
+		"#set_separator\t,",
+		"#path\tknown_services",
+		"#fields\tts\thost\tport_num\tport_proto\tservice",
+		"#types\ttime\taddr\tport\tenum\tset[string]",
+	} {
+		_, err := parser.Parse(line)
+		require.NoError(t, err)
+	}
+	logs, err := parser.Parse("1541001600.580233\t10.0.0.25\t8443\ttcp\tSSL,HTTP")
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	event := logs[0].Event().(*ZeekKnownServices)
+	require.Equal(t, Set{"SSL", "HTTP"}, event.Service)
+	require.Equal(t, aws.Uint16(8443), event.PortNum)
+}
+
+func TestZeekKnownServicesType(t *testing.T) {
+	parser := &ZeekKnownServicesParser{}
+	require.Equal(t, "Zeek.Known_Services", parser.LogType())
+}
@@ -0,0 +1,39 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// ZeekMeta holds the metadata fields that JSON log writers such as the json-streaming-logs package add to each record.
+// None of these fields are present in TSV logs or in the default JSON output of Zeek.
+// The raw line of the record is only added by parsers configured with AdapterConfig.KeepRawLine.
+// nolint:lll
+type ZeekMeta struct {
+	Path       *string `json:"_path,omitempty" description:"The name of the Zeek log that the record was written to (e.g. dns)."`
+	WriteTS    *Time   `json:"_write_ts,omitempty" description:"The time when the record was written to the log."`
+	SystemName *string `json:"_system_name,omitempty" description:"The name of the Zeek sensor that produced the record."`
+	RawLine    *string `json:"_raw_line,omitempty" description:"The original log line of the record, if the parser was configured to keep it."`
+}
+
+// rawLineSetter is implemented by all Zeek events through ZeekMeta
+type rawLineSetter interface {
+	setRawLine(line string)
+}
+
+func (m *ZeekMeta) setRawLine(line string) {
+	m.RawLine = &line
+}
@@ -0,0 +1,89 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekModbus(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CpIIXl4DFGswmjH2bl","id.orig_h":"10.1.1.10","id.orig_p":51002,"id.resp_h":"10.1.2.20","id.resp_p":502,"func":"WRITE_MULTIPLE_REGISTERS"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekModbus{
+		TS:      (*Time)(&expectedTime),
+		UID:     aws.String("CpIIXl4DFGswmjH2bl"),
+		IDOrigH: aws.String("10.1.1.10"),
+		IDOrigP: aws.Uint16(51002),
+		IDRespH: aws.String("10.1.2.20"),
+		IDRespP: aws.Uint16(502),
+		Func:    aws.String("WRITE_MULTIPLE_REGISTERS"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Modbus")
+	expectedEvent.AppendAnyIPAddress("10.1.1.10")
+	expectedEvent.AppendAnyIPAddress("10.1.2.20")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekModbus(t, log, expectedEvent)
+}
+
+func TestZeekModbusException(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CpIIXl4DFGswmjH2bl","id.orig_h":"10.1.1.10","id.orig_p":51002,"id.resp_h":"10.1.2.20","id.resp_p":502,"func":"WRITE_SINGLE_REGISTER","exception":"ILLEGAL_DATA_ADDRESS"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekModbus{
+		TS:        (*Time)(&expectedTime),
+		UID:       aws.String("CpIIXl4DFGswmjH2bl"),
+		IDOrigH:   aws.String("10.1.1.10"),
+		IDOrigP:   aws.Uint16(51002),
+		IDRespH:   aws.String("10.1.2.20"),
+		IDRespP:   aws.Uint16(502),
+		Func:      aws.String("WRITE_SINGLE_REGISTER"),
+		Exception: aws.String("ILLEGAL_DATA_ADDRESS"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Modbus")
+	expectedEvent.AppendAnyIPAddress("10.1.1.10")
+	expectedEvent.AppendAnyIPAddress("10.1.2.20")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekModbus(t, log, expectedEvent)
+}
+
+func TestZeekModbusType(t *testing.T) {
+	parser := &ZeekModbusParser{}
+	require.Equal(t, "Zeek.Modbus", parser.LogType())
+}
+
+func checkZeekModbus(t *testing.T, log string, expectedEvent *ZeekModbus) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekModbusParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
@@ -0,0 +1,202 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+type multiLog struct {
+	Path   string
+	Parser parsers.LogParser
+	Fields []string
+}
+
+// multiLogs are the Zeek logs handled by MultiParser.
+// Fields lists the fields that identify each log when a JSON line does not have a `_path` field.
+// If multiple logs match the same number of fields the first one is used.
+// nolint:lll
+var multiLogs = []multiLog{
+	{pathDNS, &ZeekDNSParser{}, []string{"trans_id", "query", "qclass", "qtype", "rcode", "answers", "TTLs"}},
+	{pathHTTP, &ZeekHTTPParser{}, []string{"trans_depth", "method", "host", "uri", "user_agent", "status_code"}},
+	{pathSSL, &ZeekSSLParser{}, []string{"cipher", "curve", "server_name", "established", "ssl_history", "ja3"}},
+	{pathFiles, &ZeekFilesParser{}, []string{"fuid", "tx_hosts", "rx_hosts", "conn_uids", "analyzers", "seen_bytes"}},
+	{pathNotice, &ZeekNoticeParser{}, []string{"note", "msg", "sub", "actions", "suppress_for"}},
+	{pathDHCP, &ZeekDHCPParser{}, []string{"uids", "client_addr", "assigned_addr", "lease_time", "msg_types"}},
+	{pathX509, &ZeekX509Parser{}, []string{"certificate.version", "certificate.serial", "certificate.subject", "certificate.issuer", "san.dns"}},
+	{pathKerberos, &ZeekKerberosParser{}, []string{"request_type", "client", "service", "forwardable", "renewable", "till"}},
+	{pathSSH, &ZeekSSHParser{}, []string{"auth_success", "auth_attempts", "cipher_alg", "mac_alg", "kex_alg", "host_key"}},
+	{pathSMTP, &ZeekSMTPParser{}, []string{"helo", "mailfrom", "rcptto", "msg_id", "subject", "first_received"}},
+	{pathWeird, &ZeekWeirdParser{}, []string{"name", "addl", "peer"}},
+	{pathDCERPC, &ZeekDCERPCParser{}, []string{"rtt", "named_pipe", "endpoint", "operation"}},
+	{pathFTP, &ZeekFTPParser{}, []string{"user", "command", "arg", "reply_code", "reply_msg", "data_channel.passive"}},
+	{pathIntel, &ZeekIntelParser{}, []string{"seen.indicator", "seen.indicator_type", "seen.where", "matched", "sources"}},
+	{pathNTLM, &ZeekNTLMParser{}, []string{"username", "hostname", "domainname", "server_nb_computer_name", "server_dns_computer_name", "server_tree_name"}},
+	{pathSMBFiles, &ZeekSMBFilesParser{}, []string{"action", "prev_name", "times.modified", "times.accessed", "times.created", "times.changed"}},
+	{pathTunnel, &ZeekTunnelParser{}, []string{"tunnel_type", "action"}},
+	{pathRDP, &ZeekRDPParser{}, []string{"cookie", "security_protocol", "keyboard_layout", "client_build", "desktop_width", "cert_type", "encryption_level"}},
+	{pathSoftware, &ZeekSoftwareParser{}, []string{"host_p", "software_type", "version.major", "version.minor", "unparsed_version"}},
+	{pathSyslog, &ZeekSyslogParser{}, []string{"facility", "severity", "message"}},
+	{pathRADIUS, &ZeekRADIUSParser{}, []string{"username", "mac", "framed_addr", "connect_info", "result", "ttl"}},
+	{pathSIP, &ZeekSIPParser{}, []string{"method", "call_id", "seq", "request_from", "request_to", "response_from", "status_msg"}},
+	{pathSNMP, &ZeekSNMPParser{}, []string{"version", "community", "get_requests", "get_bulk_requests", "get_responses", "set_requests"}},
+	{pathSMBMapping, &ZeekSMBMappingParser{}, []string{"path", "service", "native_file_system", "share_type"}},
+	{pathMySQL, &ZeekMySQLParser{}, []string{"cmd", "arg", "success", "rows", "response"}},
+	{pathPE, &ZeekPEParser{}, []string{"machine", "compile_ts", "os", "subsystem", "is_exe", "is_64bit", "section_names"}},
+	{pathDPD, &ZeekDPDParser{}, []string{"analyzer", "failure_reason"}},
+	{pathIRC, &ZeekIRCParser{}, []string{"nick", "user", "command", "value", "addl", "dcc_file_name"}},
+	{pathSOCKS, &ZeekSOCKSParser{}, []string{"version", "status", "request.host", "request_p", "bound.host", "bound_p"}},
+	{pathModbus, &ZeekModbusParser{}, []string{"func", "exception"}},
+	{pathRFB, &ZeekRFBParser{}, []string{"client_major_version", "server_major_version", "authentication_method", "share_flag", "desktop_name"}},
+	// Known hosts logs only have a host field so they are only detected by their `_path`
+	{pathKnownHosts, &ZeekKnownHostsParser{}, nil},
+	{pathKnownServices, &ZeekKnownServicesParser{}, []string{"port_num", "port_proto", "service"}},
+	{pathDNP3, &ZeekDNP3Parser{}, []string{"fc_request", "fc_reply", "iin"}},
+	{pathSignatures, &ZeekSignaturesParser{}, []string{"src_addr", "dst_addr", "sig_id", "event_msg", "sub_msg", "sig_count"}},
+	{pathStats, &ZeekStatsParser{}, []string{"mem", "pkts_proc", "pkts_dropped", "pkts_link", "events_proc"}},
+	{pathCaptureLoss, &ZeekCaptureLossParser{}, []string{"ts_delta", "gaps", "acks", "percent_lost"}},
+	{pathConn, &ZeekConnParser{}, []string{"conn_state", "history", "orig_bytes", "resp_bytes", "orig_pkts", "resp_pkts"}},
+}
+
+// MultiParser parses NDJSON streams that mix JSON lines from different Zeek logs.
+// Each line is dispatched to the parser for its `_path` field or, if the field is absent,
+// to the parser whose log has the most identifying fields present in the line.
+// Lines are parsed by the same Adapter as the parsers of each log type so that failures result in a *LineError and
+// raw lines are kept if configured. The line numbers of errors are the numbers of the lines in the mixed stream.
+// Like an Adapter, a MultiParser parses a single stream and must not be used concurrently.
+type MultiParser struct {
+	parsers  map[string]*Adapter
+	fields   map[string][]string
+	paths    []string
+	onError  func(err *LineError)
+	numLines uint64
+}
+
+var _ parsers.Interface = (*MultiParser)(nil)
+
+// NewMultiParser creates a parser for NDJSON streams with lines from multiple Zeek logs.
+// It also parses the custom log types registered with RegisterCustomZeekType before it is created.
+// The config is optional.
+func NewMultiParser(config *AdapterConfig) *MultiParser {
+	p := &MultiParser{
+		parsers: make(map[string]*Adapter, len(multiLogs)),
+		fields:  make(map[string][]string, len(multiLogs)),
+		paths:   make([]string, 0, len(multiLogs)),
+	}
+	// Errors are reported with the line numbers of the mixed stream instead of the lines of each log
+	subConfig := &AdapterConfig{}
+	if config != nil {
+		subConfig.KeepRawLine = config.KeepRawLine
+		p.onError = config.OnError
+	}
+	customMultiLogs.RLock()
+	defer customMultiLogs.RUnlock()
+	for _, logs := range [][]multiLog{multiLogs, customMultiLogs.logs} {
+		for _, log := range logs {
+			p.parsers[log.Path] = newAdapter(log.Parser, subConfig)
+			p.fields[log.Path] = log.Fields
+			p.paths = append(p.paths, log.Path)
+		}
+	}
+	return p
+}
+
+// ParseLog implements parsers.Interface
+func (p *MultiParser) ParseLog(log string) ([]*parsers.Result, error) {
+	p.numLines++
+	path, err := p.detectPath(log)
+	if err != nil {
+		return nil, p.lineError("", err)
+	}
+	parser := p.parsers[path]
+	results, err := parser.ParseLog(log)
+	if err != nil {
+		if lineErr := (*LineError)(nil); errors.As(err, &lineErr) {
+			err = lineErr.Err
+		}
+		return nil, p.lineError(parser.logType, err)
+	}
+	return results, nil
+}
+
+// skipLine counts a line of the stream that is not passed to the parser (i.e. a line that exceeds the maximum line
+// length of a LineIterator) and reports it the same way as a line that does not match any Zeek log
+func (p *MultiParser) skipLine(err error) *LineError {
+	p.numLines++
+	return p.lineError("", err)
+}
+
+func (p *MultiParser) lineError(logType string, err error) *LineError {
+	lineErr := &LineError{
+		LogType: logType,
+		Line:    p.numLines,
+		Err:     err,
+	}
+	if p.onError != nil {
+		p.onError(lineErr)
+	}
+	return lineErr
+}
+
+func (p *MultiParser) detectPath(log string) (string, error) {
+	iter := jsoniter.ConfigDefault.BorrowIterator([]byte(log))
+	defer jsoniter.ConfigDefault.ReturnIterator(iter)
+	if iter.WhatIsNext() != jsoniter.ObjectValue {
+		return "", errors.New("zeek log line is not a JSON object")
+	}
+	path := ""
+	keys := map[string]struct{}{}
+	iter.ReadObjectCB(func(iter *jsoniter.Iterator, key string) bool {
+		if key == "_path" && iter.WhatIsNext() == jsoniter.StringValue {
+			path = iter.ReadString()
+			return true
+		}
+		keys[key] = struct{}{}
+		iter.Skip()
+		return true
+	})
+	if iter.Error != nil {
+		return "", errors.Wrap(iter.Error, "invalid zeek JSON log line")
+	}
+	if path != "" {
+		if _, ok := p.parsers[path]; !ok {
+			return "", errors.Errorf("unsupported zeek log path %q", path)
+		}
+		return path, nil
+	}
+	bestPath, bestScore := "", 0
+	for _, candidate := range p.paths {
+		score := 0
+		for _, field := range p.fields[candidate] {
+			if _, ok := keys[field]; ok {
+				score++
+			}
+		}
+		if score > bestScore {
+			bestPath, bestScore = candidate, score
+		}
+	}
+	if bestPath == "" {
+		return "", errors.New("zeek log line does not match any known zeek log")
+	}
+	return bestPath, nil
+}
@@ -0,0 +1,131 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiParser(t *testing.T) {
+	// nolint:lll
+	lines := []struct {
+		Log     string
+		LogType string
+	}{
+		{
+			`{"_path":"conn","ts":1541001600.580233,"uid":"C3zRsb2bhMLFBOaz9b","id.orig_h":"172.16.2.16","id.orig_p":52856,"id.resp_h":"52.94.233.129","id.resp_p":443,"proto":"tcp"}`,
+			TypeZeekConn,
+		},
+		{
+			`{"ts":1541001600.580233,"uid":"C3zRsb2bhMLFBOaz9b","id.orig_h":"172.16.2.16","id.orig_p":52856,"id.resp_h":"52.94.233.129","id.resp_p":443,"proto":"tcp","conn_state":"SF","history":"ShADadFf","orig_pkts":12,"resp_pkts":10}`,
+			TypeZeekConn,
+		},
+		{
+			`{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","trans_id":27282,"query":"www.example.com","qtype":1,"rcode":0}`,
+			TypeZeekDNS,
+		},
+		{
+			`{"ts":1541001600.580233,"uid":"CTbLSCIJh1vFRjvc4","id.orig_h":"172.16.2.16","id.orig_p":49234,"id.resp_h":"93.184.216.34","id.resp_p":80,"trans_depth":1,"method":"GET","host":"www.example.com","uri":"/","status_code":200}`,
+			TypeZeekHTTP,
+		},
+		{
+			`{"ts":1541001600.580233,"src_addr":"172.16.2.16","note":"Signatures::Sensitive_Signature","sig_id":"http-shellshock","event_msg":"172.16.2.16: Shellshock exploit attempt","sig_count":1}`,
+			TypeZeekSignatures,
+		},
+		{
+			`{"_path":"dns","ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp"}`,
+			TypeZeekDNS,
+		},
+	}
+	parser := NewMultiParser(nil)
+	for _, line := range lines {
+		results, err := parser.ParseLog(line.Log)
+		require.NoError(t, err, line.Log)
+		require.Len(t, results, 1, line.Log)
+		require.Equal(t, line.LogType, results[0].PantherLogType, line.Log)
+	}
+}
+
+func TestMultiParserErrors(t *testing.T) {
+	parser := NewMultiParser(nil)
+	for _, log := range []string{
+		``,
+		`not json`,
+		`["ts",1541001600.580233]`,
+		`{"_path":"unknown","ts":1541001600.580233}`,
+		`{"ts":1541001600.580233,"foo":"bar"}`,
+		`{"ts":1541001600.580233,"query":`,
+		// Matches DNS fields but it is missing required fields
+		`{"ts":1541001600.580233,"query":"www.example.com"}`,
+	} {
+		require.NotPanics(t, func() {
+			results, err := parser.ParseLog(log)
+			require.Error(t, err, log)
+			require.Nil(t, results, log)
+		})
+	}
+}
+
+func TestMultiParserLineErrors(t *testing.T) {
+	var reported []*LineError
+	parser := NewMultiParser(&AdapterConfig{
+		OnError: func(err *LineError) {
+			reported = append(reported, err)
+		},
+	})
+	// nolint:lll
+	lines := []string{
+		`{"_path":"conn","ts":1541001600.580233,"uid":"C3zRsb2bhMLFBOaz9b","id.orig_h":"172.16.2.16","id.orig_p":52856,"id.resp_h":"52.94.233.129","id.resp_p":443,"proto":"tcp"}`,
+		`{"_path":"dns","ts":"foo"}`,
+		`not json`,
+		`{"_path":"conn","ts":1541001600.580233,"uid":"C3zRsb2bhMLFBOaz9b","id.orig_h":"172.16.2.16","id.orig_p":52856,"id.resp_h":"52.94.233.129","id.resp_p":443,"proto":"tcp"}`,
+	}
+	for _, line := range lines {
+		_, _ = parser.ParseLog(line)
+	}
+	require.Len(t, reported, 2)
+	require.Equal(t, TypeZeekDNS, reported[0].LogType)
+	require.Equal(t, uint64(2), reported[0].Line)
+	require.Equal(t, "", reported[1].LogType)
+	require.Equal(t, uint64(3), reported[1].Line)
+
+	_, err := parser.ParseLog(`{"_path":"dns","ts":"foo"}`)
+	lineErr := &LineError{}
+	require.True(t, errors.As(err, &lineErr))
+	require.Equal(t, uint64(5), lineErr.Line)
+	// The error of the parser is not wrapped twice
+	require.False(t, errors.As(lineErr.Err, new(*LineError)))
+}
+
+func TestMultiParserKeepRawLine(t *testing.T) {
+	// nolint:lll
+	line := `{"_path":"conn","ts":1541001600.580233,"uid":"C3zRsb2bhMLFBOaz9b","id.orig_h":"172.16.2.16","id.orig_p":52856,"id.resp_h":"52.94.233.129","id.resp_p":443,"proto":"tcp"}`
+	results, err := NewMultiParser(&AdapterConfig{KeepRawLine: true}).ParseLog(line)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, &line, results[0].Event.(*ZeekConn).RawLine)
+
+	results, err = NewMultiParser(nil).ParseLog(line)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Nil(t, results[0].Event.(*ZeekConn).RawLine)
+}
@@ -0,0 +1,109 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekMySQL struct {
+	TS       *Time   `json:"ts,omitempty" validate:"required" description:"Timestamp for when the event happened."`
+	UID      *string `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection."`
+	IDOrigH  *string `json:"id.orig_h" panther:"ip" validate:"required" description:"The originator’s IP address."`
+	IDOrigP  *uint16 `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
+	IDRespH  *string `json:"id.resp_h" panther:"ip" validate:"required" description:"The responder’s IP address."`
+	IDRespP  *uint16 `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
+	Cmd      *string `json:"cmd,omitempty" description:"The command that was issued."`
+	Arg      *string `json:"arg,omitempty" description:"The argument issued to the command (i.e. the SQL statements of a query)."`
+	Success  *bool   `json:"success,omitempty" description:"Did the server tell us that the command succeeded?"`
+	Rows     *uint64 `json:"rows,omitempty" description:"The number of affected rows, if any."`
+	Response *string `json:"response,omitempty" description:"Server message, if any."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekMySQLParser parses zeek mysql logs
+type ZeekMySQLParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekMySQLParser)(nil)
+
+func (p *ZeekMySQLParser) New() parsers.LogParser {
+	return &ZeekMySQLParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekMySQLParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekMySQL := &ZeekMySQL{}
+
+	ok, err := p.decoder.Decode(log, pathMySQL, zeekMySQL, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekMySQL.updatePantherFields(p)
+	p.Indicators.apply(&zeekMySQL.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekMySQL); err != nil {
+		return nil, err
+	}
+
+	return zeekMySQL.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekMySQLParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekMySQLParser) LogType() string {
+	return TypeZeekMySQL
+}
+
+func (event *ZeekMySQL) updatePantherFields(p *ZeekMySQLParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+}
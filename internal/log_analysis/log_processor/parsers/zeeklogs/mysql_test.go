@@ -0,0 +1,97 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekMySQL(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CiRjVW3NzK4Hdpqvnb","id.orig_h":"192.168.0.254","id.orig_p":56162,"id.resp_h":"192.168.0.33","id.resp_p":3306,"cmd":"query","arg":"SELECT * FROM accounts WHERE username = 'root'","success":true,"rows":1}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekMySQL{
+		TS:      (*Time)(&expectedTime),
+		UID:     aws.String("CiRjVW3NzK4Hdpqvnb"),
+		IDOrigH: aws.String("192.168.0.254"),
+		IDOrigP: aws.Uint16(56162),
+		IDRespH: aws.String("192.168.0.33"),
+		IDRespP: aws.Uint16(3306),
+		Cmd:     aws.String("query"),
+		Arg:     aws.String("SELECT * FROM accounts WHERE username = 'root'"),
+		Success: aws.Bool(true),
+		Rows:    aws.Uint64(1),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.MySQL")
+	expectedEvent.AppendAnyIPAddress("192.168.0.254")
+	expectedEvent.AppendAnyIPAddress("192.168.0.33")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekMySQL(t, log, expectedEvent)
+}
+
+func TestZeekMySQLMultiStatement(t *testing.T) {
+	values := strings.Repeat("('aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa'),", 2000)
+	arg := "DROP TABLE IF EXISTS t1; CREATE TABLE t1 (c VARCHAR(64));\nINSERT INTO t1 VALUES " + values + "('b'); SELECT COUNT(*) FROM t1;"
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CiRjVW3NzK4Hdpqvnb","id.orig_h":"192.168.0.254","id.orig_p":56162,"id.resp_h":"192.168.0.33","id.resp_p":3306,"cmd":"query","arg":"` + strings.ReplaceAll(arg, "\n", `\n`) + `","success":false,"response":"You have an error in your SQL syntax; check the manual that corresponds to your MySQL server version"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekMySQL{
+		TS:       (*Time)(&expectedTime),
+		UID:      aws.String("CiRjVW3NzK4Hdpqvnb"),
+		IDOrigH:  aws.String("192.168.0.254"),
+		IDOrigP:  aws.Uint16(56162),
+		IDRespH:  aws.String("192.168.0.33"),
+		IDRespP:  aws.Uint16(3306),
+		Cmd:      aws.String("query"),
+		Arg:      aws.String(arg),
+		Success:  aws.Bool(false),
+		Response: aws.String("You have an error in your SQL syntax; check the manual that corresponds to your MySQL server version"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.MySQL")
+	expectedEvent.AppendAnyIPAddress("192.168.0.254")
+	expectedEvent.AppendAnyIPAddress("192.168.0.33")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekMySQL(t, log, expectedEvent)
+}
+
+func TestZeekMySQLType(t *testing.T) {
+	parser := &ZeekMySQLParser{}
+	require.Equal(t, "Zeek.MySQL", parser.LogType())
+}
+
+func checkZeekMySQL(t *testing.T, log string, expectedEvent *ZeekMySQL) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekMySQLParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
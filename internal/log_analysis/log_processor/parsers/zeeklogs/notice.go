@@ -0,0 +1,136 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekNotice struct {
+	TS           *Time    `json:"ts,omitempty" validate:"required" description:"An absolute time indicating when the notice occurred, defaults to the current network time."`
+	UID          *string  `json:"uid,omitempty" description:"A connection UID which uniquely identifies the endpoints concerned with the notice."`
+	IDOrigH      *string  `json:"id.orig_h,omitempty" panther:"ip" description:"The originator’s IP address (if the notice concerns a connection)."`
+	IDOrigP      *uint16  `json:"id.orig_p,omitempty" description:"The originator’s port number (if the notice concerns a connection)."`
+	IDRespH      *string  `json:"id.resp_h,omitempty" panther:"ip" description:"The responder’s IP address (if the notice concerns a connection)."`
+	IDRespP      *uint16  `json:"id.resp_p,omitempty" description:"The responder’s port number (if the notice concerns a connection)."`
+	FUID         *string  `json:"fuid,omitempty" description:"A file unique ID if this notice is related to a file."`
+	FileMIMEType *string  `json:"file_mime_type,omitempty" description:"A mime type if the notice is related to a file."`
+	FileDesc     *string  `json:"file_desc,omitempty" description:"Frequently files can be “described” to give a bit more context. This field will typically be automatically filled out from an fa_file record."`
+	Proto        *string  `json:"proto,omitempty" description:"The transport protocol. Filled automatically when either conn, iconn or p is specified."`
+	Note         *string  `json:"note,omitempty" validate:"required" description:"The type of the notice (e.g. Scan::Port_Scan)."`
+	Msg          *string  `json:"msg,omitempty" description:"The human readable message for the notice."`
+	Sub          *string  `json:"sub,omitempty" description:"The human readable sub-message."`
+	Src          *string  `json:"src,omitempty" panther:"ip" description:"Source address, if we don’t have a conn_id."`
+	Dst          *string  `json:"dst,omitempty" panther:"ip" description:"Destination address."`
+	P            *uint16  `json:"p,omitempty" description:"Associated port, if we don’t have a conn_id."`
+	N            *uint64  `json:"n,omitempty" description:"Associated count, or perhaps a status code."`
+	PeerDescr    *string  `json:"peer_descr,omitempty" description:"Textual description for the peer that raised this notice, including name, host address and port."`
+	Actions      Set      `json:"actions,omitempty" description:"The actions which have been applied to this notice."`
+	SuppressFor  *float64 `json:"suppress_for,omitempty" description:"This field indicates the length of time that this unique notice should be suppressed."`
+	Dropped      *bool    `json:"dropped,omitempty" description:"Indicate if the src IP address was dropped and denied network access."`
+	EmailDest    Set      `json:"email_dest,omitempty" panther:"email" description:"The email addresses the notice was sent to (requires the email notice actions)."`
+	Suppressed   *bool    `json:"suppressed,omitempty" description:"Whether Zeek suppresses further instances of this notice, derived from a non-zero suppress_for."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekNoticeParser parses zeek notice logs
+type ZeekNoticeParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekNoticeParser)(nil)
+
+func (p *ZeekNoticeParser) New() parsers.LogParser {
+	return &ZeekNoticeParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekNoticeParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekNotice := &ZeekNotice{}
+
+	ok, err := p.decoder.Decode(log, pathNotice, zeekNotice, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekNotice.setSuppressed()
+	zeekNotice.updatePantherFields(p)
+	p.Indicators.apply(&zeekNotice.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekNotice); err != nil {
+		return nil, err
+	}
+
+	return zeekNotice.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekNoticeParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekNoticeParser) LogType() string {
+	return TypeZeekNotice
+}
+
+// setSuppressed marks notices that Zeek suppresses for a while so that further instances are not double counted
+func (event *ZeekNotice) setSuppressed() {
+	if event.SuppressFor != nil {
+		suppressed := *event.SuppressFor > 0
+		event.Suppressed = &suppressed
+	}
+}
+
+func (event *ZeekNotice) updatePantherFields(p *ZeekNoticeParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "src", event.Src)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "dst", event.Dst)
+
+	for _, email := range event.EmailDest {
+		appendEmails(&event.ZeekPantherLog, email)
+	}
+}
@@ -0,0 +1,140 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekNotice(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"93.184.216.34","id.resp_p":443,"proto":"tcp","note":"SSL::Invalid_Server_Cert","msg":"SSL certificate validation failed with (unable to get local issuer certificate)","sub":"CN=example.com","src":"172.16.2.16","dst":"93.184.216.34","p":443,"peer_descr":"worker-1","actions":["Notice::ACTION_LOG"],"suppress_for":3600.0}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekNotice{
+		TS:          (*Time)(&expectedTime),
+		UID:         aws.String("CpR9AY39cUCZ0t5qq6"),
+		IDOrigH:     aws.String("172.16.2.16"),
+		IDOrigP:     aws.Uint16(43720),
+		IDRespH:     aws.String("93.184.216.34"),
+		IDRespP:     aws.Uint16(443),
+		Proto:       aws.String("tcp"),
+		Note:        aws.String("SSL::Invalid_Server_Cert"),
+		Msg:         aws.String("SSL certificate validation failed with (unable to get local issuer certificate)"),
+		Sub:         aws.String("CN=example.com"),
+		Src:         aws.String("172.16.2.16"),
+		Dst:         aws.String("93.184.216.34"),
+		P:           aws.Uint16(443),
+		PeerDescr:   aws.String("worker-1"),
+		Actions:     []string{"Notice::ACTION_LOG"},
+		SuppressFor: aws.Float64(3600),
+		Suppressed:  aws.Bool(true),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Notice")
+	expectedEvent.AppendAnyIPAddress("172.16.2.16")
+	expectedEvent.AppendAnyIPAddress("93.184.216.34")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekNotice(t, log, expectedEvent)
+}
+
+func TestZeekNoticeWithoutConnection(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"note":"Scan::Port_Scan","msg":"172.16.2.16 scanned at least 15 unique ports of host 172.16.0.2 in 0m2s","sub":"local","src":"172.16.2.16","dst":"172.16.0.2","actions":["Notice::ACTION_LOG"],"suppress_for":3600.0}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekNotice{
+		TS:          (*Time)(&expectedTime),
+		Note:        aws.String("Scan::Port_Scan"),
+		Msg:         aws.String("172.16.2.16 scanned at least 15 unique ports of host 172.16.0.2 in 0m2s"),
+		Sub:         aws.String("local"),
+		Src:         aws.String("172.16.2.16"),
+		Dst:         aws.String("172.16.0.2"),
+		Actions:     []string{"Notice::ACTION_LOG"},
+		SuppressFor: aws.Float64(3600),
+		Suppressed:  aws.Bool(true),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Notice")
+	expectedEvent.AppendAnyIPAddress("172.16.2.16")
+	expectedEvent.AppendAnyIPAddress("172.16.0.2")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekNotice(t, log, expectedEvent)
+}
+
+func TestZeekNoticeEmail(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"note":"SSH::Password_Guessing","msg":"172.16.2.16 appears to be guessing SSH passwords (seen in 30 connections).","src":"172.16.2.16","actions":["Notice::ACTION_LOG","Notice::ACTION_EMAIL"],"email_dest":["soc@example.com","Security Team <security@example.com>"],"suppress_for":1800.0}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekNotice{
+		TS:          (*Time)(&expectedTime),
+		Note:        aws.String("SSH::Password_Guessing"),
+		Msg:         aws.String("172.16.2.16 appears to be guessing SSH passwords (seen in 30 connections)."),
+		Src:         aws.String("172.16.2.16"),
+		Actions:     []string{"Notice::ACTION_LOG", "Notice::ACTION_EMAIL"},
+		EmailDest:   []string{"soc@example.com", "Security Team <security@example.com>"},
+		SuppressFor: aws.Float64(1800),
+		Suppressed:  aws.Bool(true),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Notice")
+	expectedEvent.AppendAnyIPAddress("172.16.2.16")
+	expectedEvent.AppendAnyEmails("soc@example.com", "security@example.com")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekNotice(t, log, expectedEvent)
+}
+
+func TestZeekNoticeSuppressed(t *testing.T) {
+	for _, tc := range []struct {
+		SuppressFor string
+		Expect      *bool
+	}{
+		{``, nil},
+		{`,"suppress_for":0.0`, aws.Bool(false)},
+		{`,"suppress_for":60.0`, aws.Bool(true)},
+	} {
+		logs, err := (&ZeekNoticeParser{}).Parse(`{"ts":1541001600.580233,"note":"Scan::Port_Scan"` + tc.SuppressFor + `}`)
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		require.Equal(t, tc.Expect, logs[0].Event().(*ZeekNotice).Suppressed, tc.SuppressFor)
+	}
+}
+
+func TestZeekNoticeType(t *testing.T) {
+	parser := &ZeekNoticeParser{}
+	require.Equal(t, "Zeek.Notice", parser.LogType())
+}
+
+func checkZeekNotice(t *testing.T, log string, expectedEvent *ZeekNotice) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekNoticeParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
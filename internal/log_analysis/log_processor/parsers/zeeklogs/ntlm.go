@@ -0,0 +1,118 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekNTLM struct {
+	TS                    *Time   `json:"ts,omitempty" validate:"required" description:"Timestamp for when the event happened."`
+	UID                   *string `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection."`
+	IDOrigH               *string `json:"id.orig_h" panther:"ip" validate:"required" description:"The originator’s IP address."`
+	IDOrigP               *uint16 `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
+	IDRespH               *string `json:"id.resp_h" panther:"ip" validate:"required" description:"The responder’s IP address."`
+	IDRespP               *uint16 `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
+	Username              *string `json:"username,omitempty" panther:"username" description:"Username given by the client."`
+	Hostname              *string `json:"hostname,omitempty" panther:"hostname" description:"Hostname given by the client."`
+	DomainName            *string `json:"domainname,omitempty" description:"Domainname given by the client."`
+	ServerNBComputerName  *string `json:"server_nb_computer_name,omitempty" description:"NetBIOS name given by the server in a CHALLENGE."`
+	ServerDNSComputerName *string `json:"server_dns_computer_name,omitempty" panther:"hostname" description:"DNS name given by the server in a CHALLENGE."`
+	ServerTreeName        *string `json:"server_tree_name,omitempty" description:"Tree name given by the server in a CHALLENGE."`
+	Success               *bool   `json:"success,omitempty" description:"Indicate whether or not the authentication was successful."`
+	Status                *string `json:"status,omitempty" description:"A string representation of the status code that was returned in response to the authentication attempt."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekNTLMParser parses zeek ntlm logs
+type ZeekNTLMParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekNTLMParser)(nil)
+
+func (p *ZeekNTLMParser) New() parsers.LogParser {
+	return &ZeekNTLMParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekNTLMParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekNTLM := &ZeekNTLM{}
+
+	ok, err := p.decoder.Decode(log, pathNTLM, zeekNTLM, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekNTLM.updatePantherFields(p)
+	p.Indicators.apply(&zeekNTLM.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekNTLM); err != nil {
+		return nil, err
+	}
+
+	return zeekNTLM.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekNTLMParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekNTLMParser) LogType() string {
+	return TypeZeekNTLM
+}
+
+func (event *ZeekNTLM) updatePantherFields(p *ZeekNTLMParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+	appendHostnamePtr(&event.ZeekPantherLog, event.Hostname)
+	appendHostnamePtr(&event.ZeekPantherLog, event.ServerDNSComputerName)
+
+	if isSetPtr(event.Username) {
+		event.AppendAnyUsernamePtrs(event.Username)
+	}
+}
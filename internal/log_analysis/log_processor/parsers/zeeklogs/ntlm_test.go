@@ -0,0 +1,99 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekNTLM(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"C5Ywrq2MJQOpwfZ0Yf","id.orig_h":"192.168.10.31","id.orig_p":49244,"id.resp_h":"192.168.10.10","id.resp_p":445,"username":"jdoe","hostname":"WKS-042","domainname":"CORP","server_nb_computer_name":"DC01","server_dns_computer_name":"dc01.corp.example.com","server_tree_name":"corp.example.com","success":true,"status":"SUCCESS"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekNTLM{
+		TS:                    (*Time)(&expectedTime),
+		UID:                   aws.String("C5Ywrq2MJQOpwfZ0Yf"),
+		IDOrigH:               aws.String("192.168.10.31"),
+		IDOrigP:               aws.Uint16(49244),
+		IDRespH:               aws.String("192.168.10.10"),
+		IDRespP:               aws.Uint16(445),
+		Username:              aws.String("jdoe"),
+		Hostname:              aws.String("WKS-042"),
+		DomainName:            aws.String("CORP"),
+		ServerNBComputerName:  aws.String("DC01"),
+		ServerDNSComputerName: aws.String("dc01.corp.example.com"),
+		ServerTreeName:        aws.String("corp.example.com"),
+		Success:               aws.Bool(true),
+		Status:                aws.String("SUCCESS"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.NTLM")
+	expectedEvent.AppendAnyIPAddress("192.168.10.31")
+	expectedEvent.AppendAnyIPAddress("192.168.10.10")
+	expectedEvent.AppendAnyDomainNames("wks-042", "dc01.corp.example.com")
+	expectedEvent.AppendAnyUsernames("jdoe")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekNTLM(t, log, expectedEvent)
+}
+
+func TestZeekNTLMUsernameAndDomainOnly(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"C5Ywrq2MJQOpwfZ0Yf","id.orig_h":"192.168.10.31","id.orig_p":49244,"id.resp_h":"192.168.10.10","id.resp_p":445,"username":"svc_backup","domainname":"CORP"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekNTLM{
+		TS:         (*Time)(&expectedTime),
+		UID:        aws.String("C5Ywrq2MJQOpwfZ0Yf"),
+		IDOrigH:    aws.String("192.168.10.31"),
+		IDOrigP:    aws.Uint16(49244),
+		IDRespH:    aws.String("192.168.10.10"),
+		IDRespP:    aws.Uint16(445),
+		Username:   aws.String("svc_backup"),
+		DomainName: aws.String("CORP"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.NTLM")
+	expectedEvent.AppendAnyIPAddress("192.168.10.31")
+	expectedEvent.AppendAnyIPAddress("192.168.10.10")
+	expectedEvent.AppendAnyUsernames("svc_backup")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekNTLM(t, log, expectedEvent)
+}
+
+func TestZeekNTLMType(t *testing.T) {
+	parser := &ZeekNTLMParser{}
+	require.Equal(t, "Zeek.NTLM", parser.LogType())
+}
+
+func checkZeekNTLM(t *testing.T, log string, expectedEvent *ZeekNTLM) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekNTLMParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
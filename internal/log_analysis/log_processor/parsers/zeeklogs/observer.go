@@ -0,0 +1,79 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"sync/atomic"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// Observer is notified of the outcome of parsing each Zeek log line, i.e. to export parse metrics per log type.
+// It is called by the parsers created by the factories of Zeek log types and by MultiParser.
+// Implementations must be safe for concurrent use.
+type Observer interface {
+	// OnParsed is called for each log line that was parsed to events
+	OnParsed(logType string)
+	// OnFailed is called for each log line that failed to parse
+	OnFailed(logType string, err error)
+}
+
+// FieldObserver is an optional interface of an Observer that is notified of field values that are kept in events
+// but cannot be used as indicators, i.e. invalid IP addresses.
+type FieldObserver interface {
+	// OnInvalidField is called for each invalid value of a field
+	OnInvalidField(logType, field, value string)
+}
+
+// observerBox allows storing a nil Observer in an atomic.Value
+type observerBox struct {
+	Observer
+}
+
+var globalObserver atomic.Value
+
+// SetObserver registers the Observer notified by all Zeek parsers.
+// Setting a nil observer disables notifications, which is the default.
+func SetObserver(o Observer) {
+	globalObserver.Store(observerBox{o})
+}
+
+// observe notifies the registered Observer, if any, of the outcome of parsing a log line.
+// Lines without events (i.e TSV header directives) are not reported as parsed.
+func observe(logType string, results []*parsers.Result, err error) {
+	box, _ := globalObserver.Load().(observerBox)
+	if box.Observer == nil {
+		return
+	}
+	if err != nil {
+		box.OnFailed(logType, err)
+		return
+	}
+	if len(results) > 0 {
+		box.OnParsed(logType)
+	}
+}
+
+// observeInvalidField notifies the registered Observer, if it is a FieldObserver, of an invalid field value
+func observeInvalidField(logType, field, value string) {
+	box, _ := globalObserver.Load().(observerBox)
+	if o, ok := box.Observer.(FieldObserver); ok {
+		o.OnInvalidField(logType, field, value)
+	}
+}
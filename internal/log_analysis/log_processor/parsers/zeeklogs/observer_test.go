@@ -0,0 +1,97 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type countingObserver struct {
+	mu     sync.Mutex
+	parsed map[string]int
+	failed map[string]int
+}
+
+func (o *countingObserver) OnParsed(logType string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.parsed[logType]++
+}
+
+func (o *countingObserver) OnFailed(logType string, _ error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.failed[logType]++
+}
+
+// invalidFieldObserver records the invalid fields reported to a FieldObserver
+type invalidFieldObserver struct {
+	countingObserver
+	invalid []string
+}
+
+func (o *invalidFieldObserver) OnInvalidField(logType, field, value string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.invalid = append(o.invalid, logType+" "+field+"="+value)
+}
+
+func TestObserver(t *testing.T) {
+	observer := &countingObserver{
+		parsed: map[string]int{},
+		failed: map[string]int{},
+	}
+	SetObserver(observer)
+	defer SetObserver(nil)
+
+	// nolint:lll
+	dnsLines := []string{
+		"#fields\tts\tuid\tid.orig_h\tid.orig_p\tid.resp_h\tid.resp_p\tproto\tquery",
+		"1541001600.580233\tCpR9AY39cUCZ0t5qq6\t172.16.2.16\t43720\t172.16.0.2\t53\tudp\twww.example.com",
+		"1541001600.580233\tCpR9AY39cUCZ0t5qq7\t172.16.2.16\tfoo\t172.16.0.2\t53\tudp\twww.example.org",
+		`{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq8","id.orig_h":"172.16.2.16","id.orig_p":43722,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","query":"www.example.net"}`,
+		`{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq8"}`,
+	}
+	dnsParser, err := adapterFactory(&ZeekDNSParser{}).NewParser(nil)
+	require.NoError(t, err)
+	for _, line := range dnsLines {
+		_, _ = dnsParser.ParseLog(line)
+	}
+
+	// nolint:lll
+	multiLines := []string{
+		`{"_path":"conn","ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","conn_state":"SF"}`,
+		`{"_path":"conn","ts":"foo"}`,
+		`{"_path":"dns","ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","query":"www.example.com"}`,
+	}
+	multiParser := NewMultiParser(nil)
+	for _, line := range multiLines {
+		_, _ = multiParser.ParseLog(line)
+	}
+
+	require.Equal(t, map[string]int{TypeZeekDNS: 3, TypeZeekConn: 1}, observer.parsed)
+	require.Equal(t, map[string]int{TypeZeekDNS: 2, TypeZeekConn: 1}, observer.failed)
+
+	SetObserver(nil)
+	_, _ = dnsParser.ParseLog(dnsLines[1])
+	require.Equal(t, 3, observer.parsed[TypeZeekDNS])
+}
@@ -0,0 +1,95 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// ZeekPantherLog adds the indicator fields of Zeek logs to the panther fields of each event.
+// They are only part of the schema of Zeek log types so the tables of other log types do not change.
+// nolint(lll)
+type ZeekPantherLog struct {
+	parsers.PantherLog
+
+	PantherAnyUsernames    *parsers.PantherAnyString `json:"p_any_usernames,omitempty" description:"Panther added field with collection of usernames associated with the row"`
+	PantherAnyEmails       *parsers.PantherAnyString `json:"p_any_emails,omitempty" description:"Panther added field with collection of email addresses associated with the row"`
+	PantherAnyMACAddresses *parsers.PantherAnyString `json:"p_any_mac_addresses,omitempty" description:"Panther added field with collection of MAC addresses associated with the row"`
+	PantherAnyURLs         *parsers.PantherAnyString `json:"p_any_urls,omitempty" description:"Panther added field with collection of URLs associated with the row"`
+}
+
+func (pl *ZeekPantherLog) AppendAnyUsernamePtrs(values ...*string) {
+	for _, value := range values {
+		if value != nil {
+			pl.AppendAnyUsernames(*value)
+		}
+	}
+}
+
+func (pl *ZeekPantherLog) AppendAnyUsernames(values ...string) {
+	if pl.PantherAnyUsernames == nil { // lazy create
+		pl.PantherAnyUsernames = parsers.NewPantherAnyString()
+	}
+	parsers.AppendAnyString(pl.PantherAnyUsernames, values...)
+}
+
+func (pl *ZeekPantherLog) AppendAnyEmailPtrs(values ...*string) {
+	for _, value := range values {
+		if value != nil {
+			pl.AppendAnyEmails(*value)
+		}
+	}
+}
+
+func (pl *ZeekPantherLog) AppendAnyEmails(values ...string) {
+	if pl.PantherAnyEmails == nil { // lazy create
+		pl.PantherAnyEmails = parsers.NewPantherAnyString()
+	}
+	parsers.AppendAnyString(pl.PantherAnyEmails, values...)
+}
+
+func (pl *ZeekPantherLog) AppendAnyMACAddressPtrs(values ...*string) {
+	for _, value := range values {
+		if value != nil {
+			pl.AppendAnyMACAddresses(*value)
+		}
+	}
+}
+
+func (pl *ZeekPantherLog) AppendAnyMACAddresses(values ...string) {
+	if pl.PantherAnyMACAddresses == nil { // lazy create
+		pl.PantherAnyMACAddresses = parsers.NewPantherAnyString()
+	}
+	parsers.AppendAnyString(pl.PantherAnyMACAddresses, values...)
+}
+
+func (pl *ZeekPantherLog) AppendAnyURLPtrs(values ...*string) {
+	for _, value := range values {
+		if value != nil {
+			pl.AppendAnyURLs(*value)
+		}
+	}
+}
+
+func (pl *ZeekPantherLog) AppendAnyURLs(values ...string) {
+	if pl.PantherAnyURLs == nil { // lazy create
+		pl.PantherAnyURLs = parsers.NewPantherAnyString()
+	}
+	parsers.AppendAnyString(pl.PantherAnyURLs, values...)
+}
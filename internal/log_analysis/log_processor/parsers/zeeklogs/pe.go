@@ -0,0 +1,112 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekPE struct {
+	TS                *Time   `json:"ts,omitempty" description:"Current timestamp."`
+	ID                *string `json:"id,omitempty" validate:"required" description:"File id of this portable executable file (the fuid of the Files log)."`
+	Machine           *string `json:"machine,omitempty" description:"The target machine that the file was compiled for."`
+	CompileTS         *Time   `json:"compile_ts,omitempty" description:"The time that the file was created at."`
+	OS                *string `json:"os,omitempty" description:"The required operating system."`
+	Subsystem         *string `json:"subsystem,omitempty" description:"The subsystem that is required to run this file."`
+	IsExe             *bool   `json:"is_exe,omitempty" description:"Is the file an executable, or just an object file?"`
+	Is64Bit           *bool   `json:"is_64bit,omitempty" description:"Is the file a 64-bit executable?"`
+	UsesASLR          *bool   `json:"uses_aslr,omitempty" description:"Does the file support Address Space Layout Randomization?"`
+	UsesDEP           *bool   `json:"uses_dep,omitempty" description:"Does the file support Data Execution Prevention?"`
+	UsesCodeIntegrity *bool   `json:"uses_code_integrity,omitempty" description:"Does the file enforce code integrity checks?"`
+	UsesSEH           *bool   `json:"uses_seh,omitempty" description:"Does the file use structured exception handing?"`
+	HasImportTable    *bool   `json:"has_import_table,omitempty" description:"Does the file have an import table?"`
+	HasExportTable    *bool   `json:"has_export_table,omitempty" description:"Does the file have an export table?"`
+	HasCertTable      *bool   `json:"has_cert_table,omitempty" description:"Does the file have an attribute certificate table?"`
+	HasDebugData      *bool   `json:"has_debug_data,omitempty" description:"Does the file have a debug table?"`
+	SectionNames      Set     `json:"section_names,omitempty" description:"The names of the sections, in order."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekPEParser parses zeek pe logs
+type ZeekPEParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekPEParser)(nil)
+
+func (p *ZeekPEParser) New() parsers.LogParser {
+	return &ZeekPEParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekPEParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekPE := &ZeekPE{}
+
+	ok, err := p.decoder.Decode(log, pathPE, zeekPE, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekPE.updatePantherFields(p)
+	p.Indicators.apply(&zeekPE.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekPE); err != nil {
+		return nil, err
+	}
+
+	return zeekPE.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekPEParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekPEParser) LogType() string {
+	return TypeZeekPE
+}
+
+func (event *ZeekPE) updatePantherFields(p *ZeekPEParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+}
@@ -0,0 +1,90 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekPE(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"id":"FYFHvW3sXdXRA3SxG","machine":"AMD64","compile_ts":1539251083.0,"os":"Windows XP x64 or Server 2003","subsystem":"WINDOWS_GUI","is_exe":true,"is_64bit":true,"uses_aslr":true,"uses_dep":true,"uses_code_integrity":false,"uses_seh":true,"has_import_table":true,"has_export_table":false,"has_cert_table":true,"has_debug_data":true,"section_names":[".text",".rdata",".data",".pdata",".rsrc",".reloc"]}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedCompileTime := time.Date(2018, 10, 11, 9, 44, 43, 0, time.UTC)
+	expectedEvent := &ZeekPE{
+		TS:                (*Time)(&expectedTime),
+		ID:                aws.String("FYFHvW3sXdXRA3SxG"),
+		Machine:           aws.String("AMD64"),
+		CompileTS:         (*Time)(&expectedCompileTime),
+		OS:                aws.String("Windows XP x64 or Server 2003"),
+		Subsystem:         aws.String("WINDOWS_GUI"),
+		IsExe:             aws.Bool(true),
+		Is64Bit:           aws.Bool(true),
+		UsesASLR:          aws.Bool(true),
+		UsesDEP:           aws.Bool(true),
+		UsesCodeIntegrity: aws.Bool(false),
+		UsesSEH:           aws.Bool(true),
+		HasImportTable:    aws.Bool(true),
+		HasExportTable:    aws.Bool(false),
+		HasCertTable:      aws.Bool(true),
+		HasDebugData:      aws.Bool(true),
+		SectionNames:      []string{".text", ".rdata", ".data", ".pdata", ".rsrc", ".reloc"},
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.PE")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekPE(t, log, expectedEvent)
+}
+
+func TestZeekPEMinimal(t *testing.T) {
+	log := `{"id":"FYFHvW3sXdXRA3SxG","machine":"I386"}`
+
+	expectedEvent := &ZeekPE{
+		ID:      aws.String("FYFHvW3sXdXRA3SxG"),
+		Machine: aws.String("I386"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.PE")
+	checkZeekPE(t, log, expectedEvent)
+
+	_, err := (&ZeekPEParser{}).Parse(`{"machine":"I386"}`)
+	require.Error(t, err)
+}
+
+func TestZeekPEType(t *testing.T) {
+	parser := &ZeekPEParser{}
+	require.Equal(t, "Zeek.PE", parser.LogType())
+}
+
+func checkZeekPE(t *testing.T, log string, expectedEvent *ZeekPE) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekPEParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
@@ -0,0 +1,122 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekRADIUS struct {
+	TS          *Time    `json:"ts,omitempty" validate:"required" description:"Timestamp for when the event happened."`
+	UID         *string  `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection."`
+	IDOrigH     *string  `json:"id.orig_h" panther:"ip" validate:"required" description:"The originator’s IP address."`
+	IDOrigP     *uint16  `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
+	IDRespH     *string  `json:"id.resp_h" panther:"ip" validate:"required" description:"The responder’s IP address."`
+	IDRespP     *uint16  `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
+	Username    *string  `json:"username,omitempty" panther:"username" description:"The username, if present."`
+	MAC         *string  `json:"mac,omitempty" panther:"mac" description:"MAC address, if present."`
+	FramedAddr  *string  `json:"framed_addr,omitempty" panther:"ip" description:"The address given to the network access server, if present. This is only a hint from the RADIUS server and the network access server is not required to honor the address."`
+	RemoteIP    *string  `json:"remote_ip,omitempty" panther:"ip" description:"Remote IP address, if present. This is collected from the Tunnel-Client-Endpoint attribute."`
+	ConnectInfo *string  `json:"connect_info,omitempty" description:"Connect info, if present."`
+	ReplyMsg    *string  `json:"reply_msg,omitempty" description:"Reply message from the server challenge. This is frequently shown to the user authenticating."`
+	Result      *string  `json:"result,omitempty" description:"Successful or failed authentication (success or failed)."`
+	TTL         *float64 `json:"ttl,omitempty" description:"The duration between the first request and either the Access-Accept message or an error. If the field is empty, it means that either the request or response was not seen."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekRADIUSParser parses zeek radius logs
+type ZeekRADIUSParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekRADIUSParser)(nil)
+
+func (p *ZeekRADIUSParser) New() parsers.LogParser {
+	return &ZeekRADIUSParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekRADIUSParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekRADIUS := &ZeekRADIUS{}
+
+	ok, err := p.decoder.Decode(log, pathRADIUS, zeekRADIUS, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	if zeekRADIUS.MAC != nil {
+		*zeekRADIUS.MAC = normalizeMAC(*zeekRADIUS.MAC)
+	}
+	zeekRADIUS.updatePantherFields(p)
+	p.Indicators.apply(&zeekRADIUS.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekRADIUS); err != nil {
+		return nil, err
+	}
+
+	return zeekRADIUS.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekRADIUSParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekRADIUSParser) LogType() string {
+	return TypeZeekRADIUS
+}
+
+func (event *ZeekRADIUS) updatePantherFields(p *ZeekRADIUSParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "framed_addr", event.FramedAddr)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "remote_ip", event.RemoteIP)
+
+	if isSetPtr(event.Username) {
+		event.AppendAnyUsernamePtrs(event.Username)
+	}
+	appendMACAddressPtr(&event.ZeekPantherLog, event.MAC)
+}
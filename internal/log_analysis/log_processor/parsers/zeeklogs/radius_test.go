@@ -0,0 +1,102 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekRADIUS(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CZOSTu1nL1vJ8wv8qa","id.orig_h":"10.0.0.12","id.orig_p":50062,"id.resp_h":"10.0.0.2","id.resp_p":1812,"username":"jdoe","mac":"00-0C-29-03-DF-AD","framed_addr":"10.8.0.14","remote_ip":"203.0.113.7","connect_info":"CONNECT 802.11g","result":"success","ttl":0.010773}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekRADIUS{
+		TS:          (*Time)(&expectedTime),
+		UID:         aws.String("CZOSTu1nL1vJ8wv8qa"),
+		IDOrigH:     aws.String("10.0.0.12"),
+		IDOrigP:     aws.Uint16(50062),
+		IDRespH:     aws.String("10.0.0.2"),
+		IDRespP:     aws.Uint16(1812),
+		Username:    aws.String("jdoe"),
+		MAC:         aws.String("00:0c:29:03:df:ad"),
+		FramedAddr:  aws.String("10.8.0.14"),
+		RemoteIP:    aws.String("203.0.113.7"),
+		ConnectInfo: aws.String("CONNECT 802.11g"),
+		Result:      aws.String("success"),
+		TTL:         aws.Float64(0.010773),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.RADIUS")
+	expectedEvent.AppendAnyIPAddress("10.0.0.12")
+	expectedEvent.AppendAnyIPAddress("10.0.0.2")
+	expectedEvent.AppendAnyIPAddress("10.8.0.14")
+	expectedEvent.AppendAnyIPAddress("203.0.113.7")
+	expectedEvent.AppendAnyUsernames("jdoe")
+	expectedEvent.AppendAnyMACAddresses("00:0c:29:03:df:ad")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekRADIUS(t, log, expectedEvent)
+}
+
+func TestZeekRADIUSWithoutFramedAddr(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CZOSTu1nL1vJ8wv8qa","id.orig_h":"10.0.0.12","id.orig_p":50062,"id.resp_h":"10.0.0.2","id.resp_p":1812,"username":"jdoe","reply_msg":"Authentication failed","result":"failed","ttl":1.002146}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekRADIUS{
+		TS:       (*Time)(&expectedTime),
+		UID:      aws.String("CZOSTu1nL1vJ8wv8qa"),
+		IDOrigH:  aws.String("10.0.0.12"),
+		IDOrigP:  aws.Uint16(50062),
+		IDRespH:  aws.String("10.0.0.2"),
+		IDRespP:  aws.Uint16(1812),
+		Username: aws.String("jdoe"),
+		ReplyMsg: aws.String("Authentication failed"),
+		Result:   aws.String("failed"),
+		TTL:      aws.Float64(1.002146),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.RADIUS")
+	expectedEvent.AppendAnyIPAddress("10.0.0.12")
+	expectedEvent.AppendAnyIPAddress("10.0.0.2")
+	expectedEvent.AppendAnyUsernames("jdoe")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekRADIUS(t, log, expectedEvent)
+}
+
+func TestZeekRADIUSType(t *testing.T) {
+	parser := &ZeekRADIUSParser{}
+	require.Equal(t, "Zeek.RADIUS", parser.LogType())
+}
+
+func checkZeekRADIUS(t *testing.T, log string, expectedEvent *ZeekRADIUS) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekRADIUSParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
@@ -0,0 +1,141 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekRDP struct {
+	TS                  *Time   `json:"ts,omitempty" validate:"required" description:"Timestamp for when the event happened."`
+	UID                 *string `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection."`
+	IDOrigH             *string `json:"id.orig_h" panther:"ip" validate:"required" description:"The originator’s IP address."`
+	IDOrigP             *uint16 `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
+	IDRespH             *string `json:"id.resp_h" panther:"ip" validate:"required" description:"The responder’s IP address."`
+	IDRespP             *uint16 `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
+	Cookie              *string `json:"cookie,omitempty" panther:"username" description:"Cookie value used by the client machine. This is typically a username."`
+	Result              *string `json:"result,omitempty" description:"Status result for the connection. It’s a mix between RDP negotation failure messages and GCC server create response messages."`
+	SecurityProtocol    *string `json:"security_protocol,omitempty" description:"Security protocol chosen by the server."`
+	ClientChannels      Set     `json:"client_channels,omitempty" description:"The channels requested by the client."`
+	KeyboardLayout      *string `json:"keyboard_layout,omitempty" description:"Keyboard layout (language) of the client machine."`
+	ClientBuild         *string `json:"client_build,omitempty" description:"RDP client version used by the client machine."`
+	ClientName          *string `json:"client_name,omitempty" description:"Name of the client machine."`
+	ClientDigProductID  *string `json:"client_dig_product_id,omitempty" description:"Product ID of the client machine."`
+	DesktopWidth        *uint64 `json:"desktop_width,omitempty" description:"Desktop width of the client machine."`
+	DesktopHeight       *uint64 `json:"desktop_height,omitempty" description:"Desktop height of the client machine."`
+	RequestedColorDepth *string `json:"requested_color_depth,omitempty" description:"The color depth requested by the client in the high_color_depth field."`
+	CertType            *string `json:"cert_type,omitempty" description:"If the connection is being encrypted with native RDP encryption, this is the type of cert being used."`
+	CertCount           *uint64 `json:"cert_count,omitempty" description:"The number of certs seen. X.509 can transfer an entire certificate chain."`
+	CertPermanent       *bool   `json:"cert_permanent,omitempty" description:"Indicates if the provided certificate or certificate chain is permanent or temporary."`
+	EncryptionLevel     *string `json:"encryption_level,omitempty" description:"Encryption level of the connection."`
+	EncryptionMethod    *string `json:"encryption_method,omitempty" description:"Encryption method of the connection."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekRDPParser parses zeek rdp logs
+type ZeekRDPParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekRDPParser)(nil)
+
+func (p *ZeekRDPParser) New() parsers.LogParser {
+	return &ZeekRDPParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekRDPParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekRDP := &ZeekRDP{}
+
+	ok, err := p.decoder.Decode(log, pathRDP, zeekRDP, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekRDP.updatePantherFields(p)
+	p.Indicators.apply(&zeekRDP.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekRDP); err != nil {
+		return nil, err
+	}
+
+	return zeekRDP.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekRDPParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekRDPParser) LogType() string {
+	return TypeZeekRDP
+}
+
+func (event *ZeekRDP) updatePantherFields(p *ZeekRDPParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+
+	if username, ok := rdpCookieUsername(event.Cookie); ok {
+		event.AppendAnyUsernames(username)
+	}
+}
+
+// rdpCookiePrefix is the prefix of the routing token Microsoft clients send in the RDP cookie
+const rdpCookiePrefix = "mstshash="
+
+// rdpCookieUsername extracts the username of an RDP cookie.
+// Zeek logs the value of the `mstshash` token which may still have the `mstshash=` prefix (depending on the client).
+func rdpCookieUsername(cookie *string) (string, bool) {
+	if cookie == nil {
+		return "", false
+	}
+	username := strings.TrimSpace(*cookie)
+	if len(username) >= len(rdpCookiePrefix) && strings.EqualFold(username[:len(rdpCookiePrefix)], rdpCookiePrefix) {
+		username = username[len(rdpCookiePrefix):]
+	}
+	return username, isSet(username)
+}
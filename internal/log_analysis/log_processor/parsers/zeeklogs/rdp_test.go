@@ -0,0 +1,100 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekRDP(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CjxHm33hJ3FXlFfz0j","id.orig_h":"10.0.0.14","id.orig_p":49175,"id.resp_h":"10.0.0.20","id.resp_p":3389,"cookie":"mstshash=jdoe","result":"Success","security_protocol":"HYBRID","client_channels":["rdpdr","rdpsnd","cliprdr"],"keyboard_layout":"English - United States","client_build":"RDP 8.1","client_name":"WKS-042","desktop_width":1920,"desktop_height":1080,"requested_color_depth":"32bit","cert_type":"X.509","cert_count":2,"cert_permanent":false,"encryption_level":"Client compatible","encryption_method":"128bit"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekRDP{
+		TS:                  (*Time)(&expectedTime),
+		UID:                 aws.String("CjxHm33hJ3FXlFfz0j"),
+		IDOrigH:             aws.String("10.0.0.14"),
+		IDOrigP:             aws.Uint16(49175),
+		IDRespH:             aws.String("10.0.0.20"),
+		IDRespP:             aws.Uint16(3389),
+		Cookie:              aws.String("mstshash=jdoe"),
+		Result:              aws.String("Success"),
+		SecurityProtocol:    aws.String("HYBRID"),
+		ClientChannels:      []string{"rdpdr", "rdpsnd", "cliprdr"},
+		KeyboardLayout:      aws.String("English - United States"),
+		ClientBuild:         aws.String("RDP 8.1"),
+		ClientName:          aws.String("WKS-042"),
+		DesktopWidth:        aws.Uint64(1920),
+		DesktopHeight:       aws.Uint64(1080),
+		RequestedColorDepth: aws.String("32bit"),
+		CertType:            aws.String("X.509"),
+		CertCount:           aws.Uint64(2),
+		CertPermanent:       aws.Bool(false),
+		EncryptionLevel:     aws.String("Client compatible"),
+		EncryptionMethod:    aws.String("128bit"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.RDP")
+	expectedEvent.AppendAnyIPAddress("10.0.0.14")
+	expectedEvent.AppendAnyIPAddress("10.0.0.20")
+	expectedEvent.AppendAnyUsernames("jdoe")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekRDP(t, log, expectedEvent)
+}
+
+func TestRDPCookieUsername(t *testing.T) {
+	for input, expect := range map[string]string{
+		"mstshash=jdoe":        "jdoe",
+		"Mstshash=CORP\\admin": "CORP\\admin",
+		"AUser":                "AUser",
+		"mstshash=":            "",
+		"-":                    "",
+		"":                     "",
+	} {
+		input := input
+		username, ok := rdpCookieUsername(&input)
+		require.Equal(t, expect != "", ok, input)
+		if ok {
+			require.Equal(t, expect, username, input)
+		}
+	}
+	_, ok := rdpCookieUsername(nil)
+	require.False(t, ok)
+}
+
+func TestZeekRDPType(t *testing.T) {
+	parser := &ZeekRDPParser{}
+	require.Equal(t, "Zeek.RDP", parser.LogType())
+}
+
+func checkZeekRDP(t *testing.T, log string, expectedEvent *ZeekRDP) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekRDPParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
@@ -0,0 +1,211 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"container/list"
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// Defaults for the configuration of a Resolver
+const (
+	DefaultResolverWindow     = 10 * time.Minute
+	DefaultResolverMaxEntries = 100000
+)
+
+// DNS query types of the answers used by a Resolver
+const (
+	dnsQTypeA    = 1
+	dnsQTypeAAAA = 28
+)
+
+// ResolverConfig holds the options for a Resolver
+type ResolverConfig struct {
+	// Window is how long after a DNS answer a connection to the address is enriched, DefaultResolverWindow if zero.
+	Window time.Duration
+	// MaxEntries is the maximum number of addresses kept, DefaultResolverMaxEntries if zero.
+	// The oldest answers are evicted first.
+	MaxEntries int
+	// Indicators selects the indicator fields added to enriched events, it should match the policy of the conn parser
+	Indicators IndicatorPolicy
+}
+
+// Resolver enriches Zeek conn events with the domain name their responder address resolved to in earlier Zeek DNS
+// events, so that analysts do not have to join both logs at query time.
+// The enrichment is optional and requires feeding the events of both logs to the same Resolver in order.
+// A Resolver is not safe for concurrent use.
+type Resolver struct {
+	window     time.Duration
+	maxEntries int
+	indicators IndicatorPolicy
+	entries    map[string]*list.Element
+	// order holds the entries sorted from the most to the least recent answer
+	order *list.List
+	// latest is the time of the most recent answer
+	latest time.Time
+}
+
+type resolverEntry struct {
+	addr   string
+	domain string
+	ts     time.Time
+}
+
+// NewResolver creates a Resolver.
+// The config is optional.
+func NewResolver(config *ResolverConfig) *Resolver {
+	r := &Resolver{
+		window:     DefaultResolverWindow,
+		maxEntries: DefaultResolverMaxEntries,
+		entries:    map[string]*list.Element{},
+		order:      list.New(),
+	}
+	if config != nil {
+		if config.Window > 0 {
+			r.window = config.Window
+		}
+		if config.MaxEntries > 0 {
+			r.maxEntries = config.MaxEntries
+		}
+		r.indicators = config.Indicators
+	}
+	return r
+}
+
+// Enrich adds the answers of DNS results to the resolver and sets the resolved host of conn results.
+// Results of other log types are ignored.
+func (r *Resolver) Enrich(results ...*parsers.Result) {
+	for _, result := range results {
+		switch event := result.Event.(type) {
+		case *ZeekDNS:
+			r.AddDNS(event)
+		case *ZeekConn:
+			r.EnrichConn(event)
+		}
+	}
+}
+
+// AddDNS adds the address answers of an A or AAAA query to the resolver
+func (r *Resolver) AddDNS(event *ZeekDNS) {
+	if event.TS == nil || !isSetPtr(event.Query) {
+		return
+	}
+	if event.QType != nil && *event.QType != dnsQTypeA && *event.QType != dnsQTypeAAAA {
+		return
+	}
+	ts := time.Time(*event.TS)
+	domain := normalizeDomainName(*event.Query)
+	for _, answer := range event.Answers {
+		// Answers also include the names of CNAME records
+		if addr, ok := canonicalIPAddress(answer); ok {
+			r.add(addr, domain, ts)
+		}
+	}
+}
+
+// EnrichConn sets the resolved host of a conn event if its responder address was seen in a DNS answer within the
+// window before the connection started. It reports whether the event was enriched.
+func (r *Resolver) EnrichConn(event *ZeekConn) bool {
+	if event.TS == nil || event.IDRespH == nil {
+		return false
+	}
+	domain, ok := r.Lookup(*event.IDRespH, time.Time(*event.TS))
+	if !ok {
+		return false
+	}
+	event.ResolvedHost = &domain
+	if r.indicators.Enabled(pantherlog.FieldDomainName) {
+		appendDomainName(&event.ZeekPantherLog, domain)
+	}
+	return true
+}
+
+// Lookup returns the domain name an address resolved to within the window before `ts`
+func (r *Resolver) Lookup(addr string, ts time.Time) (string, bool) {
+	addr, ok := canonicalIPAddress(addr)
+	if !ok {
+		return "", false
+	}
+	el, ok := r.entries[addr]
+	if !ok {
+		return "", false
+	}
+	entry := el.Value.(*resolverEntry)
+	if ts.Before(entry.ts) || ts.Sub(entry.ts) > r.window {
+		return "", false
+	}
+	return entry.domain, true
+}
+
+// Len returns the number of addresses in the resolver
+func (r *Resolver) Len() int {
+	return r.order.Len()
+}
+
+func (r *Resolver) add(addr, domain string, ts time.Time) {
+	if r.latest.Sub(ts) > r.window {
+		// Answers that arrive out of order after their window has passed would be evicted right away
+		return
+	}
+	if el, ok := r.entries[addr]; ok {
+		entry := el.Value.(*resolverEntry)
+		if ts.Before(entry.ts) {
+			return
+		}
+		entry.domain, entry.ts = domain, ts
+		r.order.Remove(el)
+		r.entries[addr] = r.insert(entry)
+	} else {
+		r.entries[addr] = r.insert(&resolverEntry{
+			addr:   addr,
+			domain: domain,
+			ts:     ts,
+		})
+	}
+	if ts.After(r.latest) {
+		r.latest = ts
+	}
+	r.evict()
+}
+
+// insert adds an entry to the list keeping it sorted by time.
+// Answers are mostly in order so the position is searched from the front.
+func (r *Resolver) insert(entry *resolverEntry) *list.Element {
+	for el := r.order.Front(); el != nil; el = el.Next() {
+		if !entry.ts.Before(el.Value.(*resolverEntry).ts) {
+			return r.order.InsertBefore(entry, el)
+		}
+	}
+	return r.order.PushBack(entry)
+}
+
+// evict removes the entries that are past the window of the latest answer and the oldest entries over the maximum
+func (r *Resolver) evict() {
+	for el := r.order.Back(); el != nil; el = r.order.Back() {
+		entry := el.Value.(*resolverEntry)
+		if r.order.Len() <= r.maxEntries && r.latest.Sub(entry.ts) <= r.window {
+			return
+		}
+		r.order.Remove(el)
+		delete(r.entries, entry.addr)
+	}
+}
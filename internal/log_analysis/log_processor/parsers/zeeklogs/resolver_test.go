@@ -0,0 +1,183 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// nolint:lll
+const (
+	resolverDNSLog  = `{"ts":1541001600.580233,"uid":"CpEvSd3bATYFZGZrh2","id.orig_h":"172.16.2.16","id.orig_p":47365,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","trans_id":20563,"query":"Dynamodb.US-West-2.amazonaws.com.","qtype":1,"qtype_name":"A","rcode":0,"answers":["dynamodb.us-west-2.amazonaws.com","52.94.10.82"],"TTLs":[5.0,5.0]}`
+	resolverConnLog = `{"ts":1541001660.580233,"uid":"C3zRsb2bhMLFBOaz9b","id.orig_h":"172.16.2.16","id.orig_p":52856,"id.resp_h":"52.94.10.82","id.resp_p":443,"proto":"tcp"}`
+)
+
+func TestResolverMatch(t *testing.T) {
+	resolver := NewResolver(nil)
+	results := append(parseZeekResults(t, &ZeekDNSParser{}, resolverDNSLog), parseZeekResults(t, &ZeekConnParser{}, resolverConnLog)...)
+	resolver.Enrich(results...)
+
+	conn := results[1].Event.(*ZeekConn)
+	require.NotNil(t, conn.ResolvedHost)
+	require.Equal(t, "dynamodb.us-west-2.amazonaws.com", *conn.ResolvedHost)
+	expected := parsers.PantherLog{}
+	expected.AppendAnyDomainNames("dynamodb.us-west-2.amazonaws.com")
+	require.Equal(t, expected.PantherAnyDomainNames, conn.PantherAnyDomainNames)
+}
+
+func TestResolverNoMatch(t *testing.T) {
+	resolver := NewResolver(nil)
+	resolver.Enrich(parseZeekResults(t, &ZeekDNSParser{}, resolverDNSLog)...)
+
+	conn := parseZeekConn(t, resolverConnLog)
+	conn.IDRespH = aws.String("52.94.10.83")
+	require.False(t, resolver.EnrichConn(conn))
+	require.Nil(t, conn.ResolvedHost)
+	require.Nil(t, conn.PantherAnyDomainNames)
+
+	// Connections before the answer are not enriched
+	conn = parseZeekConn(t, resolverConnLog)
+	ts := time.Time(*conn.TS).Add(-time.Hour)
+	conn.TS = (*Time)(&ts)
+	require.False(t, resolver.EnrichConn(conn))
+	require.Nil(t, conn.ResolvedHost)
+}
+
+func TestResolverExpired(t *testing.T) {
+	resolver := NewResolver(&ResolverConfig{
+		Window: 30 * time.Second,
+	})
+	resolver.Enrich(parseZeekResults(t, &ZeekDNSParser{}, resolverDNSLog)...)
+	require.Equal(t, 1, resolver.Len())
+
+	// The connection is a minute after the answer
+	conn := parseZeekConn(t, resolverConnLog)
+	require.False(t, resolver.EnrichConn(conn))
+	require.Nil(t, conn.ResolvedHost)
+
+	// Stale entries are evicted by newer answers
+	dns := parseZeekResults(t, &ZeekDNSParser{}, resolverDNSLog)[0].Event.(*ZeekDNS)
+	ts := time.Time(*dns.TS).Add(time.Minute)
+	dns.TS = (*Time)(&ts)
+	dns.Answers = []string{"52.94.10.83"}
+	resolver.AddDNS(dns)
+	require.Equal(t, 1, resolver.Len())
+	_, ok := resolver.Lookup("52.94.10.82", ts)
+	require.False(t, ok)
+}
+
+func TestResolverMaxEntries(t *testing.T) {
+	resolver := NewResolver(&ResolverConfig{
+		MaxEntries: 2,
+	})
+	dns := parseZeekResults(t, &ZeekDNSParser{}, resolverDNSLog)[0].Event.(*ZeekDNS)
+	dns.Answers = []string{"52.94.10.81", "52.94.10.82", "::ffff:52.94.10.83"}
+	resolver.AddDNS(dns)
+	require.Equal(t, 2, resolver.Len())
+
+	ts := time.Time(*dns.TS)
+	_, ok := resolver.Lookup("52.94.10.81", ts)
+	require.False(t, ok)
+	domain, ok := resolver.Lookup("52.94.10.83", ts)
+	require.True(t, ok)
+	require.Equal(t, "dynamodb.us-west-2.amazonaws.com", domain)
+}
+
+func TestResolverOutOfOrder(t *testing.T) {
+	resolver := NewResolver(&ResolverConfig{
+		Window:     time.Minute,
+		MaxEntries: 2,
+	})
+	dns := parseZeekResults(t, &ZeekDNSParser{}, resolverDNSLog)[0].Event.(*ZeekDNS)
+	ts := time.Time(*dns.TS)
+	addAnswer := func(addr string, offset time.Duration) {
+		answerTS := ts.Add(offset)
+		dns.TS = (*Time)(&answerTS)
+		dns.Answers = []string{addr}
+		resolver.AddDNS(dns)
+	}
+	addAnswer("52.94.10.82", 30*time.Second)
+	// An older answer that arrives later is evicted before the newer one
+	addAnswer("52.94.10.81", 0)
+	addAnswer("52.94.10.83", 40*time.Second)
+	require.Equal(t, 2, resolver.Len())
+	_, ok := resolver.Lookup("52.94.10.81", ts)
+	require.False(t, ok)
+	_, ok = resolver.Lookup("52.94.10.82", ts.Add(40*time.Second))
+	require.True(t, ok)
+
+	// An answer past the window of the latest answer is dropped
+	addAnswer("52.94.10.84", -time.Minute)
+	require.Equal(t, 2, resolver.Len())
+	_, ok = resolver.Lookup("52.94.10.84", ts.Add(-time.Minute))
+	require.False(t, ok)
+
+	// Expired entries are evicted even if they were added after newer entries
+	resolver = NewResolver(&ResolverConfig{
+		Window: time.Minute,
+	})
+	addAnswer("52.94.10.82", 30*time.Second)
+	addAnswer("52.94.10.81", 0)
+	addAnswer("52.94.10.83", 80*time.Second)
+	require.Equal(t, 2, resolver.Len())
+	_, ok = resolver.Lookup("52.94.10.81", ts)
+	require.False(t, ok)
+}
+
+func TestResolverIndicators(t *testing.T) {
+	resolver := NewResolver(&ResolverConfig{
+		Indicators: IndicatorPolicy{
+			Disabled: pantherlog.FieldSet{pantherlog.FieldDomainName},
+		},
+	})
+	resolver.Enrich(parseZeekResults(t, &ZeekDNSParser{}, resolverDNSLog)...)
+
+	conn := parseZeekConn(t, resolverConnLog)
+	require.True(t, resolver.EnrichConn(conn))
+	require.Equal(t, aws.String("dynamodb.us-west-2.amazonaws.com"), conn.ResolvedHost)
+	require.Nil(t, conn.PantherAnyDomainNames)
+}
+
+func TestResolverIgnoresOtherQueryTypes(t *testing.T) {
+	resolver := NewResolver(nil)
+	dns := parseZeekResults(t, &ZeekDNSParser{}, resolverDNSLog)[0].Event.(*ZeekDNS)
+	dns.QType = aws.Uint64(16)
+	resolver.AddDNS(dns)
+	require.Equal(t, 0, resolver.Len())
+}
+
+func parseZeekResults(t *testing.T, parser interface {
+	ParseString(string) ([]*parsers.Result, error)
+}, log string) []*parsers.Result {
+	results, err := parser.ParseString(log)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	return results
+}
+
+func parseZeekConn(t *testing.T, log string) *ZeekConn {
+	return parseZeekResults(t, &ZeekConnParser{}, log)[0].Event.(*ZeekConn)
+}
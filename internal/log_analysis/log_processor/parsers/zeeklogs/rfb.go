@@ -0,0 +1,114 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekRFB struct {
+	TS                   *Time   `json:"ts,omitempty" validate:"required" description:"Timestamp for when the event happened."`
+	UID                  *string `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection."`
+	IDOrigH              *string `json:"id.orig_h" panther:"ip" validate:"required" description:"The originator’s IP address."`
+	IDOrigP              *uint16 `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
+	IDRespH              *string `json:"id.resp_h" panther:"ip" validate:"required" description:"The responder’s IP address."`
+	IDRespP              *uint16 `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
+	ClientMajorVersion   *string `json:"client_major_version,omitempty" description:"Major version of the client."`
+	ClientMinorVersion   *string `json:"client_minor_version,omitempty" description:"Minor version of the client."`
+	ServerMajorVersion   *string `json:"server_major_version,omitempty" description:"Major version of the server."`
+	ServerMinorVersion   *string `json:"server_minor_version,omitempty" description:"Minor version of the server."`
+	AuthenticationMethod *string `json:"authentication_method,omitempty" description:"Identifier of authentication method used."`
+	Auth                 *bool   `json:"auth,omitempty" description:"Whether or not authentication was successful."`
+	ShareFlag            *bool   `json:"share_flag,omitempty" description:"Whether the client has an exclusive or a shared session."`
+	DesktopName          *string `json:"desktop_name,omitempty" description:"Name of the screen that is being shared."`
+	Width                *uint64 `json:"width,omitempty" description:"Width of the screen that is being shared."`
+	Height               *uint64 `json:"height,omitempty" description:"Height of the screen that is being shared."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekRFBParser parses zeek rfb logs
+type ZeekRFBParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekRFBParser)(nil)
+
+func (p *ZeekRFBParser) New() parsers.LogParser {
+	return &ZeekRFBParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekRFBParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekRFB := &ZeekRFB{}
+
+	ok, err := p.decoder.Decode(log, pathRFB, zeekRFB, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekRFB.updatePantherFields(p)
+	p.Indicators.apply(&zeekRFB.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekRFB); err != nil {
+		return nil, err
+	}
+
+	return zeekRFB.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekRFBParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekRFBParser) LogType() string {
+	return TypeZeekRFB
+}
+
+func (event *ZeekRFB) updatePantherFields(p *ZeekRFBParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+}
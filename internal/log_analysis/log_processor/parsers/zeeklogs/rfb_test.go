@@ -0,0 +1,74 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekRFB(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CXxtxq3cBY5lb8Cbl8","id.orig_h":"192.168.1.10","id.orig_p":58412,"id.resp_h":"192.168.1.20","id.resp_p":5900,"client_major_version":"003","client_minor_version":"008","server_major_version":"003","server_minor_version":"008","authentication_method":"VNC","auth":true,"share_flag":false,"desktop_name":"ops-workstation","width":1920,"height":1080}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekRFB{
+		TS:                   (*Time)(&expectedTime),
+		UID:                  aws.String("CXxtxq3cBY5lb8Cbl8"),
+		IDOrigH:              aws.String("192.168.1.10"),
+		IDOrigP:              aws.Uint16(58412),
+		IDRespH:              aws.String("192.168.1.20"),
+		IDRespP:              aws.Uint16(5900),
+		ClientMajorVersion:   aws.String("003"),
+		ClientMinorVersion:   aws.String("008"),
+		ServerMajorVersion:   aws.String("003"),
+		ServerMinorVersion:   aws.String("008"),
+		AuthenticationMethod: aws.String("VNC"),
+		Auth:                 aws.Bool(true),
+		ShareFlag:            aws.Bool(false),
+		DesktopName:          aws.String("ops-workstation"),
+		Width:                aws.Uint64(1920),
+		Height:               aws.Uint64(1080),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.RFB")
+	expectedEvent.AppendAnyIPAddress("192.168.1.10")
+	expectedEvent.AppendAnyIPAddress("192.168.1.20")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekRFB(t, log, expectedEvent)
+}
+
+func TestZeekRFBType(t *testing.T) {
+	parser := &ZeekRFBParser{}
+	require.Equal(t, "Zeek.RFB", parser.LogType())
+}
+
+func checkZeekRFB(t *testing.T, log string, expectedEvent *ZeekRFB) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekRFBParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
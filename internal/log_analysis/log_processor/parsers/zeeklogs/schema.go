@@ -0,0 +1,118 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/pantherlog"
+)
+
+// FieldDescriptor describes a field of a Zeek log schema
+type FieldDescriptor struct {
+	// Name is the JSON name of the field
+	Name string
+	// Type is the Go type of the field (e.g. `*string`, `zeeklogs.Set`)
+	Type string
+	// Description is the description of the field used in the Glue table
+	Description string
+	// Indicators are the JSON names of the panther fields the values of the field are added to
+	Indicators []string
+	// Required is set if events without a value for the field fail validation
+	Required bool
+	// Nullable is set if the field can be omitted from an event
+	Nullable bool
+}
+
+// FieldDescriptors describes the fields of a registered Zeek log type in schema order.
+// The fields of the embedded ZeekMeta are included, the panther fields common to all log types are not.
+func FieldDescriptors(logType string) ([]FieldDescriptor, error) {
+	if !strings.HasPrefix(logType, logTypePrefix) {
+		return nil, errors.Errorf("%q is not a zeek log type", logType)
+	}
+	entry := logtypes.DefaultRegistry().Get(logType)
+	if entry == nil {
+		return nil, errors.Errorf("unregistered zeek log type %q", logType)
+	}
+	return appendFieldDescriptors(nil, reflect.TypeOf(entry.Schema())), nil
+}
+
+const logTypePrefix = "Zeek."
+
+func appendFieldDescriptors(fields []FieldDescriptor, typ reflect.Type) []FieldDescriptor {
+	typ = derefType(typ)
+	if typ.Kind() != reflect.Struct {
+		return fields
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Anonymous {
+			if field.Type != typPantherLog {
+				fields = appendFieldDescriptors(fields, field.Type)
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		kind := field.Type.Kind()
+		fields = append(fields, FieldDescriptor{
+			Name:        name,
+			Type:        field.Type.String(),
+			Description: field.Tag.Get("description"),
+			Indicators:  fieldIndicators(field.Tag.Get(pantherlog.TagName)),
+			Required:    hasTagOption(field.Tag.Get("validate"), "required"),
+			Nullable:    kind == reflect.Ptr || kind == reflect.Slice || kind == reflect.Map,
+		})
+	}
+	return fields
+}
+
+// fieldIndicators resolves the panther fields of the scanner named in a `panther` struct tag
+func fieldIndicators(tag string) (indicators []string) {
+	name := strings.Split(tag, ",")[0]
+	if name == "" {
+		return nil
+	}
+	_, ids := pantherlog.LookupScanner(name)
+	for _, id := range ids {
+		indicators = append(indicators, pantherlog.FieldNameJSON(id))
+	}
+	return indicators
+}
+
+func hasTagOption(tag, option string) bool {
+	for _, opt := range strings.Split(tag, ",") {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+func TestFieldDescriptorsDNS(t *testing.T) {
+	fields, err := FieldDescriptors(TypeZeekDNS)
+	require.NoError(t, err)
+	byName := map[string]FieldDescriptor{}
+	for _, field := range fields {
+		require.False(t, strings.HasPrefix(field.Name, "p_"), field.Name)
+		byName[field.Name] = field
+	}
+	require.Equal(t, "ts", fields[0].Name)
+
+	ts := byName["ts"]
+	require.Equal(t, "*zeeklogs.Time", ts.Type)
+	require.True(t, ts.Required)
+	require.True(t, ts.Nullable)
+	require.Empty(t, ts.Indicators)
+	require.NotEmpty(t, ts.Description)
+
+	require.Equal(t, []string{"p_any_ip_addresses"}, byName["id.orig_h"].Indicators)
+	require.Equal(t, []string{"p_any_domain_names"}, byName["query"].Indicators)
+	answers := byName["answers"]
+	require.Equal(t, "zeeklogs.StringArray", answers.Type)
+	require.False(t, answers.Required)
+	require.ElementsMatch(t, []string{"p_any_domain_names", "p_any_ip_addresses"}, answers.Indicators)
+
+	path, ok := byName["_path"]
+	require.True(t, ok)
+	require.False(t, path.Required)
+}
+
+func TestFieldDescriptorsAllTypes(t *testing.T) {
+	for _, logType := range logtypes.DefaultRegistry().LogTypes() {
+		if !strings.HasPrefix(logType, "Zeek.") {
+			continue
+		}
+		fields, err := FieldDescriptors(logType)
+		require.NoError(t, err, logType)
+		require.NotEmpty(t, fields, logType)
+		names := map[string]bool{}
+		for _, field := range fields {
+			require.False(t, names[field.Name], "duplicate field %q in %s", field.Name, logType)
+			names[field.Name] = true
+		}
+	}
+}
+
+// TestFieldDescriptorsIndicators checks that the indicators of the descriptors, which are derived from the `panther`
+// struct tags, match the indicators the parsers add for the values of each field.
+func TestFieldDescriptorsIndicators(t *testing.T) {
+	probes := []string{
+		"10.1.1.1",
+		"www.example.com",
+		"http://www.example.com/index.html",
+		"user@example.com",
+		"aa:bb:cc:dd:ee:ff",
+		"d41d8cd98f00b204e9800998ecf8427e",
+		"da39a3ee5e6b4b0d3255bfef95601890afd80709",
+		"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		"admin",
+	}
+	// Fields that are set after parsing
+	enriched := map[string]bool{
+		TypeZeekConn + " resolved_host": true,
+	}
+	for _, logType := range LogTypes() {
+		fields, err := FieldDescriptors(logType)
+		require.NoError(t, err, logType)
+		parser, err := logtypes.DefaultRegistry().MustGet(logType).NewParser(nil)
+		require.NoError(t, err, logType)
+		// The required fields are set to values that are not added to the indicators
+		base := map[string]interface{}{}
+		for _, field := range fields {
+			if !field.Required {
+				continue
+			}
+			switch field.Type {
+			case "*zeeklogs.Time":
+				base[field.Name] = 1541001600.580233
+			case "*string":
+				base[field.Name] = tsvDefaultUnsetField
+			default:
+				base[field.Name] = 0
+			}
+		}
+		baseline := parseIndicators(t, parser, base)
+		require.NotNil(t, baseline, logType)
+		for _, field := range fields {
+			var value func(probe string) interface{}
+			switch field.Type {
+			case "*string":
+				value = func(probe string) interface{} { return probe }
+			case "zeeklogs.Set", "zeeklogs.StringArray":
+				value = func(probe string) interface{} { return []string{probe} }
+			default:
+				continue
+			}
+			emitted := false
+			for _, probe := range probes {
+				event := map[string]interface{}{field.Name: value(probe)}
+				for name, value := range base {
+					if name != field.Name {
+						event[name] = value
+					}
+				}
+				for indicator := range parseIndicators(t, parser, event) {
+					if baseline[indicator] {
+						continue
+					}
+					require.Contains(t, field.Indicators, indicator, "%s field %q=%q", logType, field.Name, probe)
+					emitted = true
+				}
+			}
+			if len(field.Indicators) > 0 && !enriched[logType+" "+field.Name] {
+				require.True(t, emitted, "%s field %q adds no indicators", logType, field.Name)
+			}
+		}
+	}
+}
+
+// parseIndicators returns the names of the indicator fields of the results of parsing an event.
+// Events that fail to parse have no indicators.
+func parseIndicators(t *testing.T, parser parsers.Interface, event map[string]interface{}) map[string]bool {
+	line, err := json.Marshal(event)
+	require.NoError(t, err)
+	results, err := parser.ParseLog(string(line))
+	if err != nil {
+		return nil
+	}
+	indicators := map[string]bool{}
+	for _, result := range results {
+		data, err := jsoniter.Marshal(result)
+		require.NoError(t, err)
+		fields := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(data, &fields))
+		for name := range fields {
+			if strings.HasPrefix(name, "p_any_") {
+				indicators[name] = true
+			}
+		}
+	}
+	return indicators
+}
+
+func TestFieldDescriptorsUnknown(t *testing.T) {
+	_, err := FieldDescriptors("Zeek.Unknown")
+	require.Error(t, err)
+	_, err = FieldDescriptors("AWS.CloudTrail")
+	require.Error(t, err)
+}
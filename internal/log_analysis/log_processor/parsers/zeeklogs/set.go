@@ -0,0 +1,106 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+// Set is a Zeek `set` or `vector` of strings.
+// JSON logs write these as arrays, but logs converted from TSV may contain the TSV string form with elements joined by
+// the set separator. Both forms decode to the same value.
+type Set []string
+
+func (s *Set) UnmarshalJSON(data []byte) error {
+	iter := jsoniter.ConfigDefault.BorrowIterator(data)
+	defer jsoniter.ConfigDefault.ReturnIterator(iter)
+	switch iter.WhatIsNext() {
+	case jsoniter.NilValue:
+		*s = nil
+		return nil
+	case jsoniter.StringValue:
+		*s = splitSet(iter.ReadString(), tsvDefaultSetSeparator, tsvDefaultEmptyField, tsvDefaultUnsetField)
+		return iter.Error
+	case jsoniter.ArrayValue:
+		values, err := readStrings(iter)
+		if err != nil {
+			return err
+		}
+		*s = values
+		return nil
+	default:
+		return errors.Errorf("invalid zeek set %s", data)
+	}
+}
+
+// StringArray is a Zeek `set` or `vector` of strings with free form elements that can contain the set separator.
+// Unlike Set, a JSON string is never split to elements but decodes verbatim to a single element.
+// TSV rows are split on the `#set_separator` of the header (which Zeek escapes inside elements) before decoding.
+type StringArray []string
+
+func (a *StringArray) UnmarshalJSON(data []byte) error {
+	iter := jsoniter.ConfigDefault.BorrowIterator(data)
+	defer jsoniter.ConfigDefault.ReturnIterator(iter)
+	switch iter.WhatIsNext() {
+	case jsoniter.NilValue:
+		*a = nil
+		return nil
+	case jsoniter.StringValue:
+		*a = StringArray{iter.ReadString()}
+		return iter.Error
+	case jsoniter.ArrayValue:
+		values, err := readStrings(iter)
+		if err != nil {
+			return err
+		}
+		*a = values
+		return nil
+	default:
+		return errors.Errorf("invalid zeek string array %s", data)
+	}
+}
+
+func readStrings(iter *jsoniter.Iterator) ([]string, error) {
+	values := make([]string, 0, 1)
+	iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+		values = append(values, iter.ReadString())
+		return true
+	})
+	return values, iter.Error
+}
+
+// splitSet splits the TSV form of a Zeek set to its elements.
+// The unset value is decoded as nil and the empty value as a non-nil empty slice.
+// Elements are split before un-escaping so that escaped separators inside an element are preserved.
+func splitSet(value, separator, empty, unset string) []string {
+	switch value {
+	case unset:
+		return nil
+	case empty:
+		return []string{}
+	}
+	values := strings.Split(value, separator)
+	for i, el := range values {
+		values[i] = unescapeTSV(el)
+	}
+	return values
+}
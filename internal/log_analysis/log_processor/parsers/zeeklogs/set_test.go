@@ -0,0 +1,107 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetUnmarshalJSON(t *testing.T) {
+	for input, expect := range map[string]Set{
+		`["a","b,c"]`: {"a", "b,c"},
+		`[]`:          {},
+		`null`:        nil,
+		`"a,b,c"`:     {"a", "b", "c"},
+		`"a"`:         {"a"},
+		`"a\\x2cb,c"`: {"a,b", "c"},
+		`"(empty)"`:   {},
+		`"-"`:         nil,
+		`["a","-"]`:   {"a", "-"},
+		`["(empty)"]`: {"(empty)"},
+	} {
+		var s Set
+		require.NoError(t, jsoniter.UnmarshalFromString(input, &s), input)
+		require.Equal(t, expect, s, input)
+	}
+	var s Set
+	require.Error(t, jsoniter.UnmarshalFromString(`42`, &s))
+	require.Error(t, jsoniter.UnmarshalFromString(`[1,2]`, &s))
+}
+
+func TestStringArrayUnmarshalJSON(t *testing.T) {
+	for input, expect := range map[string]StringArray{
+		`["a","b,c"]`:  {"a", "b,c"},
+		`[]`:           {},
+		`null`:         nil,
+		`"a,b,c"`:      {"a,b,c"},
+		`"(empty)"`:    {"(empty)"},
+		`["\"a, b\""]`: {`"a, b"`},
+	} {
+		var a StringArray
+		require.NoError(t, jsoniter.UnmarshalFromString(input, &a), input)
+		require.Equal(t, expect, a, input)
+	}
+	var a StringArray
+	require.Error(t, jsoniter.UnmarshalFromString(`42`, &a))
+	require.Error(t, jsoniter.UnmarshalFromString(`[1,2]`, &a))
+}
+
+func TestSplitSet(t *testing.T) {
+	require.Equal(t, []string{"a", "b"}, splitSet("a|b", "|", "(empty)", "-"))
+	require.Equal(t, []string{"a,b"}, splitSet("a,b", "|", "(empty)", "-"))
+	require.Equal(t, []string{}, splitSet("EMPTY", "|", "EMPTY", "UNSET"))
+	require.Nil(t, splitSet("UNSET", "|", "EMPTY", "UNSET"))
+}
+
+func TestSetJSONAndTSV(t *testing.T) {
+	// nolint:lll
+	jsonLog := `{"ts":1541001600.580233,"fuid":"FjY1Lu2RmCY6Ct1wZe","tx_hosts":["93.184.216.34","93.184.216.35"],"rx_hosts":[],"conn_uids":["CwFs1P2UcUdlSxD2La"]}`
+	// nolint:lll
+	tsvLog := `{"ts":1541001600.580233,"fuid":"FjY1Lu2RmCY6Ct1wZe","tx_hosts":"93.184.216.34,93.184.216.35","rx_hosts":"(empty)","conn_uids":"CwFs1P2UcUdlSxD2La","analyzers":"-"}`
+	var fromJSON, fromTSV ZeekFiles
+	require.NoError(t, jsoniter.UnmarshalFromString(jsonLog, &fromJSON))
+	require.NoError(t, jsoniter.UnmarshalFromString(tsvLog, &fromTSV))
+	require.Equal(t, fromJSON, fromTSV)
+	require.NotNil(t, fromTSV.RxHosts)
+	require.Empty(t, fromTSV.RxHosts)
+	require.Nil(t, fromTSV.Analyzers)
+
+	parser := (&ZeekFilesParser{}).New()
+	for _, line := range []string{
+		`#separator \x09`,
+		"#set_separator\t|",
+		"#path\tfiles",
+		"#fields\tts\tfuid\ttx_hosts\trx_hosts\tconn_uids\tanalyzers",
+		"#types\ttime\tstring\tset[addr]\tset[addr]\tset[string]\tset[string]",
+	} {
+		_, err := parser.Parse(line)
+		require.NoError(t, err)
+	}
+	logs, err := parser.Parse("1541001600.580233\tFjY1Lu2RmCY6Ct1wZe\t93.184.216.34|93.184.216.35\t(empty)\tCwFs1P2UcUdlSxD2La\t-")
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	event := logs[0].Event().(*ZeekFiles)
+	require.Equal(t, fromJSON.TxHosts, event.TxHosts)
+	require.Equal(t, fromJSON.RxHosts, event.RxHosts)
+	require.Equal(t, fromJSON.ConnUIDs, event.ConnUIDs)
+	require.Nil(t, event.Analyzers)
+}
@@ -0,0 +1,110 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekSignatures struct {
+	TS        *Time   `json:"ts,omitempty" validate:"required" description:"The network time at which a signature matching type of event to be logged has occurred."`
+	UID       *string `json:"uid,omitempty" description:"A unique identifier of the connection which triggered the signature match event."`
+	SrcAddr   *string `json:"src_addr,omitempty" panther:"ip" description:"The host which triggered the signature match event."`
+	SrcPort   *uint16 `json:"src_port,omitempty" description:"The host port on which the signature-matching activity occurred."`
+	DstAddr   *string `json:"dst_addr,omitempty" panther:"ip" description:"The destination host which was sent the payload that triggered the signature match."`
+	DstPort   *uint16 `json:"dst_port,omitempty" description:"The destination host port which was sent the payload that triggered the signature match."`
+	Note      *string `json:"note,omitempty" validate:"required" description:"Notice associated with signature event (e.g. Signatures::Sensitive_Signature)."`
+	SigID     *string `json:"sig_id,omitempty" description:"The name of the signature that matched."`
+	EventMsg  *string `json:"event_msg,omitempty" description:"A more descriptive message of the signature-matching event."`
+	SubMsg    *string `json:"sub_msg,omitempty" description:"Extracted payload data or extra message."`
+	SigCount  *uint64 `json:"sig_count,omitempty" description:"Number of sigs, usually from summary count."`
+	HostCount *uint64 `json:"host_count,omitempty" description:"Number of hosts, from a summary count."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekSignaturesParser parses zeek signatures logs
+type ZeekSignaturesParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekSignaturesParser)(nil)
+
+func (p *ZeekSignaturesParser) New() parsers.LogParser {
+	return &ZeekSignaturesParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekSignaturesParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekSignatures := &ZeekSignatures{}
+
+	ok, err := p.decoder.Decode(log, pathSignatures, zeekSignatures, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekSignatures.updatePantherFields(p)
+	p.Indicators.apply(&zeekSignatures.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekSignatures); err != nil {
+		return nil, err
+	}
+
+	return zeekSignatures.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekSignaturesParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekSignaturesParser) LogType() string {
+	return TypeZeekSignatures
+}
+
+func (event *ZeekSignatures) updatePantherFields(p *ZeekSignaturesParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "src_addr", event.SrcAddr)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "dst_addr", event.DstAddr)
+}
@@ -0,0 +1,76 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestZeekSignatures(t *testing.T) {
+	checkZeekFixture(t, zeekFixture{
+		LogType: TypeZeekSignatures,
+		// nolint:lll
+		Log: `{"ts":1541001600.580233,"uid":"CHhAvVGS1DHFjwGM9","src_addr":"172.16.2.16","src_port":49152,"dst_addr":"93.184.216.34","dst_port":80,"note":"Signatures::Sensitive_Signature","sig_id":"http-shellshock","event_msg":"172.16.2.16: Shellshock exploit attempt","sub_msg":"GET /cgi-bin/test.cgi HTTP/1.1","sig_count":1,"host_count":1}`,
+		Expect: `{
+			"ts": "2018-10-31 16:00:00.580233000",
+			"uid": "CHhAvVGS1DHFjwGM9",
+			"src_addr": "172.16.2.16",
+			"src_port": 49152,
+			"dst_addr": "93.184.216.34",
+			"dst_port": 80,
+			"note": "Signatures::Sensitive_Signature",
+			"sig_id": "http-shellshock",
+			"event_msg": "172.16.2.16: Shellshock exploit attempt",
+			"sub_msg": "GET /cgi-bin/test.cgi HTTP/1.1",
+			"sig_count": 1,
+			"host_count": 1,
+			"p_log_type": "Zeek.Signatures",
+			"p_event_time": "2018-10-31 16:00:00.580233000",
+			"p_any_ip_addresses": ["172.16.2.16", "93.184.216.34"]
+		}`,
+		Golden: "signatures",
+	})
+}
+
+func TestZeekSignaturesSummary(t *testing.T) {
+	// Summary notices for multiple hosts have no connection
+	checkZeekFixture(t, zeekFixture{
+		LogType: TypeZeekSignatures,
+		// nolint:lll
+		Log: `{"ts":1541001600.580233,"src_addr":"172.16.2.16","note":"Signatures::Multiple_Sig_Responders","sig_id":"http-shellshock","event_msg":"172.16.2.16 has triggered signature http-shellshock on 5 hosts","host_count":5}`,
+		Expect: `{
+			"ts": "2018-10-31 16:00:00.580233000",
+			"src_addr": "172.16.2.16",
+			"note": "Signatures::Multiple_Sig_Responders",
+			"sig_id": "http-shellshock",
+			"event_msg": "172.16.2.16 has triggered signature http-shellshock on 5 hosts",
+			"host_count": 5,
+			"p_log_type": "Zeek.Signatures",
+			"p_event_time": "2018-10-31 16:00:00.580233000",
+			"p_any_ip_addresses": ["172.16.2.16"]
+		}`,
+	})
+}
+
+func TestZeekSignaturesType(t *testing.T) {
+	parser := &ZeekSignaturesParser{}
+	require.Equal(t, "Zeek.Signatures", parser.LogType())
+}
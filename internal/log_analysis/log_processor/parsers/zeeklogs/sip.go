@@ -0,0 +1,161 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net"
+	"strings"
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekSIP struct {
+	TS              *Time   `json:"ts,omitempty" validate:"required" description:"Timestamp for when the request happened."`
+	UID             *string `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection."`
+	IDOrigH         *string `json:"id.orig_h" panther:"ip" validate:"required" description:"The originator’s IP address."`
+	IDOrigP         *uint16 `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
+	IDRespH         *string `json:"id.resp_h" panther:"ip" validate:"required" description:"The responder’s IP address."`
+	IDRespP         *uint16 `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
+	TransDepth      *uint64 `json:"trans_depth,omitempty" description:"Represents the pipelined depth into the connection of this request/response transaction."`
+	Method          *string `json:"method,omitempty" description:"Verb used in the SIP request (INVITE, REGISTER etc.)."`
+	URI             *string `json:"uri,omitempty" description:"URI used in the request."`
+	Date            *string `json:"date,omitempty" description:"Contents of the Date: header from the client."`
+	RequestFrom     *string `json:"request_from,omitempty" description:"Contents of the request From: header. Note: The tag= value that’s usually appended to the sender is stripped off and not logged."`
+	RequestTo       *string `json:"request_to,omitempty" description:"Contents of the To: header."`
+	ResponseFrom    *string `json:"response_from,omitempty" description:"Contents of the response From: header. Note: The tag= value that’s usually appended to the sender is stripped off and not logged."`
+	ResponseTo      *string `json:"response_to,omitempty" description:"Contents of the response To: header."`
+	ReplyTo         *string `json:"reply_to,omitempty" description:"Contents of the Reply-To: header."`
+	CallID          *string `json:"call_id,omitempty" description:"Contents of the Call-ID: header from the client."`
+	Seq             *string `json:"seq,omitempty" description:"Contents of the CSeq: header from the client."`
+	Subject         *string `json:"subject,omitempty" description:"Contents of the Subject: header from the client."`
+	RequestPath     Set     `json:"request_path,omitempty" description:"The client message transmission path, as extracted from the headers."`
+	ResponsePath    Set     `json:"response_path,omitempty" description:"The server message transmission path, as extracted from the headers."`
+	UserAgent       *string `json:"user_agent,omitempty" description:"Contents of the User-Agent: header from the client."`
+	StatusCode      *uint64 `json:"status_code,omitempty" description:"Status code returned by the server."`
+	StatusMsg       *string `json:"status_msg,omitempty" description:"Status message returned by the server."`
+	Warning         *string `json:"warning,omitempty" description:"Contents of the Warning: header."`
+	RequestBodyLen  *uint64 `json:"request_body_len,omitempty" description:"Contents of the Content-Length: header from the client."`
+	ResponseBodyLen *uint64 `json:"response_body_len,omitempty" description:"Contents of the Content-Length: header from the server."`
+	ContentType     *string `json:"content_type,omitempty" description:"Contents of the Content-Type: header from the server."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekSIPParser parses zeek sip logs
+type ZeekSIPParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekSIPParser)(nil)
+
+func (p *ZeekSIPParser) New() parsers.LogParser {
+	return &ZeekSIPParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekSIPParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekSIP := &ZeekSIP{}
+
+	ok, err := p.decoder.Decode(log, pathSIP, zeekSIP, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekSIP.updatePantherFields(p)
+	p.Indicators.apply(&zeekSIP.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekSIP); err != nil {
+		return nil, err
+	}
+
+	return zeekSIP.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekSIPParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekSIPParser) LogType() string {
+	return TypeZeekSIP
+}
+
+func (event *ZeekSIP) updatePantherFields(p *ZeekSIPParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+
+	for _, value := range []*string{event.RequestFrom, event.RequestTo, event.ResponseFrom, event.ResponseTo} {
+		if value == nil {
+			continue
+		}
+		if host, ok := sipURIHost(*value); ok {
+			appendHostname(&event.ZeekPantherLog, host)
+		}
+	}
+}
+
+// sipURIHost returns the host of the SIP URI in the value of a From: or To: header.
+// Header values can have a display name and parameters, i.e. `"Alice" <sip:alice@atlanta.example.com:5060>;tag=1928`.
+func sipURIHost(value string) (string, bool) {
+	lower := strings.ToLower(value)
+	var uri string
+	if i := strings.Index(lower, "sips:"); i >= 0 {
+		uri = value[i+len("sips:"):]
+	} else if i := strings.Index(lower, "sip:"); i >= 0 {
+		uri = value[i+len("sip:"):]
+	} else {
+		return "", false
+	}
+	if end := strings.IndexAny(uri, ">;? "); end >= 0 {
+		uri = uri[:end]
+	}
+	if at := strings.LastIndexByte(uri, '@'); at >= 0 {
+		uri = uri[at+1:]
+	}
+	if host, _, err := net.SplitHostPort(uri); err == nil {
+		uri = host
+	}
+	host := strings.Trim(uri, "[]")
+	return host, host != ""
+}
@@ -0,0 +1,132 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekSIP(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CPRLCB4eWHdjP852Bk","id.orig_h":"172.16.133.19","id.orig_p":5060,"id.resp_h":"74.63.41.218","id.resp_p":5060,"trans_depth":0,"method":"REGISTER","uri":"sip:newyork.voip.ms:5060","request_from":"\"Alice\" <sip:alice@newyork.voip.ms:5060>","request_to":"<sip:alice@newyork.voip.ms:5060>","response_from":"\"Alice\" <sip:alice@newyork.voip.ms:5060>","response_to":"<sip:alice@newyork.voip.ms:5060>;tag=as023f66a5","call_id":"8694cd7e-976e4fc3-d76f6e38@172.16.133.19","seq":"4127 REGISTER","request_path":["SIP/2.0/UDP 172.16.133.19:5060"],"response_path":["SIP/2.0/UDP 172.16.133.19:5060"],"user_agent":"PolycomSoundPointIP-SPIP_550-UA/3.3.1.0933","status_code":401,"status_msg":"Unauthorized","request_body_len":0,"response_body_len":0}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekSIP{
+		TS:              (*Time)(&expectedTime),
+		UID:             aws.String("CPRLCB4eWHdjP852Bk"),
+		IDOrigH:         aws.String("172.16.133.19"),
+		IDOrigP:         aws.Uint16(5060),
+		IDRespH:         aws.String("74.63.41.218"),
+		IDRespP:         aws.Uint16(5060),
+		TransDepth:      aws.Uint64(0),
+		Method:          aws.String("REGISTER"),
+		URI:             aws.String("sip:newyork.voip.ms:5060"),
+		RequestFrom:     aws.String(`"Alice" <sip:alice@newyork.voip.ms:5060>`),
+		RequestTo:       aws.String("<sip:alice@newyork.voip.ms:5060>"),
+		ResponseFrom:    aws.String(`"Alice" <sip:alice@newyork.voip.ms:5060>`),
+		ResponseTo:      aws.String("<sip:alice@newyork.voip.ms:5060>;tag=as023f66a5"),
+		CallID:          aws.String("8694cd7e-976e4fc3-d76f6e38@172.16.133.19"),
+		Seq:             aws.String("4127 REGISTER"),
+		RequestPath:     []string{"SIP/2.0/UDP 172.16.133.19:5060"},
+		ResponsePath:    []string{"SIP/2.0/UDP 172.16.133.19:5060"},
+		UserAgent:       aws.String("PolycomSoundPointIP-SPIP_550-UA/3.3.1.0933"),
+		StatusCode:      aws.Uint64(401),
+		StatusMsg:       aws.String("Unauthorized"),
+		RequestBodyLen:  aws.Uint64(0),
+		ResponseBodyLen: aws.Uint64(0),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.SIP")
+	expectedEvent.AppendAnyIPAddress("172.16.133.19")
+	expectedEvent.AppendAnyIPAddress("74.63.41.218")
+	expectedEvent.AppendAnyDomainNames("newyork.voip.ms")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekSIP(t, log, expectedEvent)
+}
+
+func TestZeekSIPRequestWithoutResponse(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CPRLCB4eWHdjP852Bk","id.orig_h":"198.51.100.23","id.orig_p":5072,"id.resp_h":"172.16.133.19","id.resp_p":5060,"trans_depth":0,"method":"OPTIONS","uri":"sip:100@172.16.133.19","request_from":"\"sipvicious\"<sip:100@1.1.1.1>","request_to":"\"sipvicious\"<sip:100@1.1.1.1>","call_id":"60306236313238656162346664366461","seq":"1 OPTIONS","user_agent":"friendly-scanner"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekSIP{
+		TS:          (*Time)(&expectedTime),
+		UID:         aws.String("CPRLCB4eWHdjP852Bk"),
+		IDOrigH:     aws.String("198.51.100.23"),
+		IDOrigP:     aws.Uint16(5072),
+		IDRespH:     aws.String("172.16.133.19"),
+		IDRespP:     aws.Uint16(5060),
+		TransDepth:  aws.Uint64(0),
+		Method:      aws.String("OPTIONS"),
+		URI:         aws.String("sip:100@172.16.133.19"),
+		RequestFrom: aws.String(`"sipvicious"<sip:100@1.1.1.1>`),
+		RequestTo:   aws.String(`"sipvicious"<sip:100@1.1.1.1>`),
+		CallID:      aws.String("60306236313238656162346664366461"),
+		Seq:         aws.String("1 OPTIONS"),
+		UserAgent:   aws.String("friendly-scanner"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.SIP")
+	expectedEvent.AppendAnyIPAddress("198.51.100.23")
+	expectedEvent.AppendAnyIPAddress("172.16.133.19")
+	expectedEvent.AppendAnyIPAddress("1.1.1.1")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekSIP(t, log, expectedEvent)
+}
+
+func TestSIPURIHost(t *testing.T) {
+	for value, expected := range map[string]string{
+		`"Alice" <sip:alice@atlanta.example.com>;tag=1928301774`: "atlanta.example.com",
+		`<sips:bob@biloxi.example.com:5061>`:                     "biloxi.example.com",
+		`sip:carol@chicago.example.com`:                          "chicago.example.com",
+		`<SIP:chicago.example.com;transport=tcp>`:                "chicago.example.com",
+		`<sip:alice@[2001:db8::10]:5060>`:                        "2001:db8::10",
+		`<sip:alice@[2001:db8::10]>`:                             "2001:db8::10",
+		`sip:+15555550100@198.51.100.1?subject=call`:             "198.51.100.1",
+	} {
+		host, ok := sipURIHost(value)
+		require.True(t, ok, value)
+		require.Equal(t, expected, host, value)
+	}
+	for _, value := range []string{"", "-", "Alice", "<sip:>", "<tel:+15555550100>"} {
+		_, ok := sipURIHost(value)
+		require.False(t, ok, value)
+	}
+}
+
+func TestZeekSIPType(t *testing.T) {
+	parser := &ZeekSIPParser{}
+	require.Equal(t, "Zeek.SIP", parser.LogType())
+}
+
+func checkZeekSIP(t *testing.T, log string, expectedEvent *ZeekSIP) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekSIPParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
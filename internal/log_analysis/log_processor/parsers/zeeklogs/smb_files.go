@@ -0,0 +1,114 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekSMBFiles struct {
+	TS            *Time   `json:"ts,omitempty" validate:"required" description:"Time when the file was first discovered."`
+	UID           *string `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection."`
+	IDOrigH       *string `json:"id.orig_h" panther:"ip" validate:"required" description:"The originator’s IP address."`
+	IDOrigP       *uint16 `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
+	IDRespH       *string `json:"id.resp_h" panther:"ip" validate:"required" description:"The responder’s IP address."`
+	IDRespP       *uint16 `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
+	FUID          *string `json:"fuid,omitempty" description:"Unique ID of the file."`
+	Action        *string `json:"action,omitempty" description:"Action this log record represents (e.g. SMB::FILE_OPEN)."`
+	SharePath     *string `json:"path,omitempty" description:"Path pulled from the tree this file was transferred to or from."`
+	Name          *string `json:"name,omitempty" description:"Filename if one was seen."`
+	Size          *uint64 `json:"size,omitempty" description:"Total size of the file."`
+	PrevName      *string `json:"prev_name,omitempty" description:"If the rename action was seen, this will be the file’s previous name."`
+	TimesModified *Time   `json:"times.modified,omitempty" description:"The time when data was last written to the file."`
+	TimesAccessed *Time   `json:"times.accessed,omitempty" description:"The time when the file was last accessed."`
+	TimesCreated  *Time   `json:"times.created,omitempty" description:"The time the file was created."`
+	TimesChanged  *Time   `json:"times.changed,omitempty" description:"The time when the file was last modified."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekSMBFilesParser parses zeek smb_files logs
+type ZeekSMBFilesParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekSMBFilesParser)(nil)
+
+func (p *ZeekSMBFilesParser) New() parsers.LogParser {
+	return &ZeekSMBFilesParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekSMBFilesParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekSMBFiles := &ZeekSMBFiles{}
+
+	ok, err := p.decoder.Decode(log, pathSMBFiles, zeekSMBFiles, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekSMBFiles.updatePantherFields(p)
+	p.Indicators.apply(&zeekSMBFiles.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekSMBFiles); err != nil {
+		return nil, err
+	}
+
+	return zeekSMBFiles.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekSMBFilesParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekSMBFilesParser) LogType() string {
+	return TypeZeekSMBFiles
+}
+
+func (event *ZeekSMBFiles) updatePantherFields(p *ZeekSMBFilesParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+}
@@ -0,0 +1,100 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekSMBFiles(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"C9YAaEzWLL62yWMn5","id.orig_h":"192.168.10.31","id.orig_p":1030,"id.resp_h":"192.168.10.10","id.resp_p":445,"fuid":"Fo4quf1cRXzyFiAobc","action":"SMB::FILE_OPEN","path":"\\\\admin-pc\\ADMIN$","name":"PSEXESVC.exe","size":145568,"times.modified":1507565599.607085,"times.accessed":1507565599.607085,"times.created":1507565599.607085,"times.changed":1507565599.607085}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	fileTime := time.Date(2017, 10, 9, 16, 13, 19, 607085000, time.UTC)
+	expectedEvent := &ZeekSMBFiles{
+		TS:            (*Time)(&expectedTime),
+		UID:           aws.String("C9YAaEzWLL62yWMn5"),
+		IDOrigH:       aws.String("192.168.10.31"),
+		IDOrigP:       aws.Uint16(1030),
+		IDRespH:       aws.String("192.168.10.10"),
+		IDRespP:       aws.Uint16(445),
+		FUID:          aws.String("Fo4quf1cRXzyFiAobc"),
+		Action:        aws.String("SMB::FILE_OPEN"),
+		SharePath:     aws.String(`\\admin-pc\ADMIN$`),
+		Name:          aws.String("PSEXESVC.exe"),
+		Size:          aws.Uint64(145568),
+		TimesModified: (*Time)(&fileTime),
+		TimesAccessed: (*Time)(&fileTime),
+		TimesCreated:  (*Time)(&fileTime),
+		TimesChanged:  (*Time)(&fileTime),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.SMB_Files")
+	expectedEvent.AppendAnyIPAddress("192.168.10.31")
+	expectedEvent.AppendAnyIPAddress("192.168.10.10")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekSMBFiles(t, log, expectedEvent)
+}
+
+func TestZeekSMBFilesWithoutSize(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"C9YAaEzWLL62yWMn5","id.orig_h":"192.168.10.31","id.orig_p":1030,"id.resp_h":"192.168.10.10","id.resp_p":445,"action":"SMB::FILE_RENAME","path":"\\\\fs01\\share","name":"report.docx.locked","prev_name":"report.docx"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekSMBFiles{
+		TS:        (*Time)(&expectedTime),
+		UID:       aws.String("C9YAaEzWLL62yWMn5"),
+		IDOrigH:   aws.String("192.168.10.31"),
+		IDOrigP:   aws.Uint16(1030),
+		IDRespH:   aws.String("192.168.10.10"),
+		IDRespP:   aws.Uint16(445),
+		Action:    aws.String("SMB::FILE_RENAME"),
+		SharePath: aws.String(`\\fs01\share`),
+		Name:      aws.String("report.docx.locked"),
+		PrevName:  aws.String("report.docx"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.SMB_Files")
+	expectedEvent.AppendAnyIPAddress("192.168.10.31")
+	expectedEvent.AppendAnyIPAddress("192.168.10.10")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekSMBFiles(t, log, expectedEvent)
+}
+
+func TestZeekSMBFilesType(t *testing.T) {
+	parser := &ZeekSMBFilesParser{}
+	require.Equal(t, "Zeek.SMB_Files", parser.LogType())
+}
+
+func checkZeekSMBFiles(t *testing.T, log string, expectedEvent *ZeekSMBFiles) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekSMBFilesParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
@@ -0,0 +1,108 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekSMBMapping struct {
+	TS               *Time   `json:"ts,omitempty" validate:"required" description:"Time when the tree was mapped."`
+	UID              *string `json:"uid,omitempty" description:"A unique identifier of the connection."`
+	IDOrigH          *string `json:"id.orig_h,omitempty" panther:"ip" description:"The originator’s IP address."`
+	IDOrigP          *uint16 `json:"id.orig_p,omitempty" description:"The originator’s port number."`
+	IDRespH          *string `json:"id.resp_h,omitempty" panther:"ip" description:"The responder’s IP address."`
+	IDRespP          *uint16 `json:"id.resp_p,omitempty" description:"The responder’s port number."`
+	SharePath        *string `json:"path,omitempty" description:"Name of the tree path (i.e. \\\\server\\C$)."`
+	Service          *string `json:"service,omitempty" description:"The type of resource of the tree (disk share, printer share, named pipe, etc.)."`
+	NativeFileSystem *string `json:"native_file_system,omitempty" description:"File system of the tree."`
+	ShareType        *string `json:"share_type,omitempty" description:"If this is SMB2, a share type will be included. For SMB1, the type of share will be deduced and included as well."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekSMBMappingParser parses zeek smb_mapping logs
+type ZeekSMBMappingParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekSMBMappingParser)(nil)
+
+func (p *ZeekSMBMappingParser) New() parsers.LogParser {
+	return &ZeekSMBMappingParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekSMBMappingParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekSMBMapping := &ZeekSMBMapping{}
+
+	ok, err := p.decoder.Decode(log, pathSMBMapping, zeekSMBMapping, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekSMBMapping.updatePantherFields(p)
+	p.Indicators.apply(&zeekSMBMapping.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekSMBMapping); err != nil {
+		return nil, err
+	}
+
+	return zeekSMBMapping.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekSMBMappingParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekSMBMappingParser) LogType() string {
+	return TypeZeekSMBMapping
+}
+
+func (event *ZeekSMBMapping) updatePantherFields(p *ZeekSMBMappingParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+}
@@ -0,0 +1,115 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekSMBMapping(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"C9YAaEzWLL62yWMn5","id.orig_h":"192.168.10.31","id.orig_p":1232,"id.resp_h":"192.168.10.10","id.resp_p":445,"path":"\\\\DC1\\ADMIN$","service":"A:","native_file_system":"NTFS","share_type":"DISK"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekSMBMapping{
+		TS:               (*Time)(&expectedTime),
+		UID:              aws.String("C9YAaEzWLL62yWMn5"),
+		IDOrigH:          aws.String("192.168.10.31"),
+		IDOrigP:          aws.Uint16(1232),
+		IDRespH:          aws.String("192.168.10.10"),
+		IDRespP:          aws.Uint16(445),
+		SharePath:        aws.String(`\\DC1\ADMIN$`),
+		Service:          aws.String("A:"),
+		NativeFileSystem: aws.String("NTFS"),
+		ShareType:        aws.String("DISK"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.SMB_Mapping")
+	expectedEvent.AppendAnyIPAddress("192.168.10.31")
+	expectedEvent.AppendAnyIPAddress("192.168.10.10")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekSMBMapping(t, log, expectedEvent)
+}
+
+func TestZeekSMBMappingTSV(t *testing.T) {
+	lines := []string{
+		`#separator \x09`,
+		"#path\tsmb_mapping",
+		"#fields\tts\tuid\tid.orig_h\tid.orig_p\tid.resp_h\tid.resp_p\tpath\tservice\tnative_file_system\tshare_type",
+		`1541001600.580233	C9YAaEzWLL62yWMn5	192.168.10.31	1232	192.168.10.10	445	\\192.168.10.10\C$	-	-	DISK`,
+	}
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekSMBMapping{
+		TS:        (*Time)(&expectedTime),
+		UID:       aws.String("C9YAaEzWLL62yWMn5"),
+		IDOrigH:   aws.String("192.168.10.31"),
+		IDOrigP:   aws.Uint16(1232),
+		IDRespH:   aws.String("192.168.10.10"),
+		IDRespP:   aws.Uint16(445),
+		SharePath: aws.String(`\\192.168.10.10\C$`),
+		ShareType: aws.String("DISK"),
+	}
+	expectedEvent.SetCoreFields(TypeZeekSMBMapping, (*timestamp.RFC3339)(&expectedTime), expectedEvent)
+	expectedEvent.AppendAnyIPAddress("192.168.10.31")
+	expectedEvent.AppendAnyIPAddress("192.168.10.10")
+
+	parser := &ZeekSMBMappingParser{}
+	for _, line := range lines[:len(lines)-1] {
+		logs, err := parser.Parse(line)
+		require.NoError(t, err)
+		require.Nil(t, logs)
+	}
+	logs, err := parser.Parse(lines[len(lines)-1])
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
+
+func TestZeekSMBMappingOnlyTimestamp(t *testing.T) {
+	log := `{"ts":1541001600.580233}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekSMBMapping{
+		TS: (*Time)(&expectedTime),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.SMB_Mapping")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekSMBMapping(t, log, expectedEvent)
+}
+
+func TestZeekSMBMappingType(t *testing.T) {
+	parser := &ZeekSMBMappingParser{}
+	require.Equal(t, "Zeek.SMB_Mapping", parser.LogType())
+}
+
+func checkZeekSMBMapping(t *testing.T, log string, expectedEvent *ZeekSMBMapping) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekSMBMappingParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
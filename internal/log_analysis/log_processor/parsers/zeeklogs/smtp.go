@@ -0,0 +1,138 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekSMTP struct {
+	TS             *Time   `json:"ts,omitempty" validate:"required" description:"Time when the message was first seen."`
+	UID            *string `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection."`
+	IDOrigH        *string `json:"id.orig_h" panther:"ip" validate:"required" description:"The originator’s IP address."`
+	IDOrigP        *uint16 `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
+	IDRespH        *string `json:"id.resp_h" panther:"ip" validate:"required" description:"The responder’s IP address."`
+	IDRespP        *uint16 `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
+	TransDepth     *uint64 `json:"trans_depth,omitempty" description:"A count to represent the depth of this message transaction in a single connection where multiple messages were transferred."`
+	Helo           *string `json:"helo,omitempty" description:"Contents of the Helo header."`
+	MailFrom       *string `json:"mailfrom,omitempty" panther:"email" description:"Email addresses found in the From header."`
+	RcptTo         Set     `json:"rcptto,omitempty" panther:"email" description:"Email addresses found in the Rcpt header."`
+	Date           *string `json:"date,omitempty" description:"Contents of the Date header."`
+	From           *string `json:"from,omitempty" panther:"email" description:"Contents of the From header."`
+	To             Set     `json:"to,omitempty" panther:"email" description:"Contents of the To header."`
+	CC             Set     `json:"cc,omitempty" panther:"email" description:"Contents of the CC header."`
+	ReplyTo        *string `json:"reply_to,omitempty" panther:"email" description:"Contents of the ReplyTo header."`
+	MsgID          *string `json:"msg_id,omitempty" description:"Contents of the MsgID header."`
+	InReplyTo      *string `json:"in_reply_to,omitempty" description:"Contents of the In-Reply-To header."`
+	Subject        *string `json:"subject,omitempty" description:"Contents of the Subject header."`
+	XOriginatingIP *string `json:"x_originating_ip,omitempty" panther:"ip" description:"Contents of the X-Originating-IP header."`
+	FirstReceived  *string `json:"first_received,omitempty" description:"Contents of the first Received header."`
+	SecondReceived *string `json:"second_received,omitempty" description:"Contents of the second Received header."`
+	LastReply      *string `json:"last_reply,omitempty" description:"The last message that the server sent to the client."`
+	MailPath       Set     `json:"path,omitempty" panther:"ip" description:"The message transmission path, as extracted from the headers."`
+	UserAgent      *string `json:"user_agent,omitempty" description:"Value of the User-Agent header from the client."`
+	TLS            *bool   `json:"tls,omitempty" description:"Indicates that the connection has switched to using TLS."`
+	FUIDs          Set     `json:"fuids,omitempty" description:"An ordered vector of file unique IDs seen attached to the message."`
+	IsWebmail      *bool   `json:"is_webmail,omitempty" description:"Boolean indicator of if the message was sent through a webmail interface."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekSMTPParser parses zeek smtp logs
+type ZeekSMTPParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekSMTPParser)(nil)
+
+func (p *ZeekSMTPParser) New() parsers.LogParser {
+	return &ZeekSMTPParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekSMTPParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekSMTP := &ZeekSMTP{}
+
+	ok, err := p.decoder.Decode(log, pathSMTP, zeekSMTP, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekSMTP.updatePantherFields(p)
+	p.Indicators.apply(&zeekSMTP.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekSMTP); err != nil {
+		return nil, err
+	}
+
+	return zeekSMTP.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekSMTPParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekSMTPParser) LogType() string {
+	return TypeZeekSMTP
+}
+
+func (event *ZeekSMTP) updatePantherFields(p *ZeekSMTPParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "x_originating_ip", event.XOriginatingIP)
+	for i := range event.MailPath {
+		appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "path", &event.MailPath[i])
+	}
+
+	appendEmailsPtr(&event.ZeekPantherLog, event.MailFrom)
+	appendEmailsPtr(&event.ZeekPantherLog, event.From)
+	appendEmailsPtr(&event.ZeekPantherLog, event.ReplyTo)
+	for _, addrs := range [][]string{event.RcptTo, event.To, event.CC} {
+		for _, addr := range addrs {
+			appendEmails(&event.ZeekPantherLog, addr)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekSMTP(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CmES5u32sYpV7JYN","id.orig_h":"10.10.1.4","id.orig_p":1470,"id.resp_h":"74.53.140.153","id.resp_p":25,"trans_depth":1,"helo":"GP","mailfrom":"gurpartap@patriots.in","rcptto":["raj_deol2002in@yahoo.co.in"],"date":"Mon, 5 Oct 2009 11:36:07 +0530","from":"\"Gurpartap Singh\" <gurpartap@patriots.in>","to":["<raj_deol2002in@yahoo.co.in>"],"reply_to":"Support <support@patriots.in>","msg_id":"<000301ca4581$ef9e57f0$cedb07d0$@in>","subject":"SMTP","first_received":"from GP ([10.10.1.4]) by example.com","last_reply":"250 OK id=1Mugho-0003Dg-Un","path":["74.53.140.153","10.10.1.4"],"user_agent":"Microsoft Office Outlook 12.0","tls":false,"fuids":["Fel9gs4OtNEV6gUJZ5"],"is_webmail":false}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekSMTP{
+		TS:            (*Time)(&expectedTime),
+		UID:           aws.String("CmES5u32sYpV7JYN"),
+		IDOrigH:       aws.String("10.10.1.4"),
+		IDOrigP:       aws.Uint16(1470),
+		IDRespH:       aws.String("74.53.140.153"),
+		IDRespP:       aws.Uint16(25),
+		TransDepth:    aws.Uint64(1),
+		Helo:          aws.String("GP"),
+		MailFrom:      aws.String("gurpartap@patriots.in"),
+		RcptTo:        []string{"raj_deol2002in@yahoo.co.in"},
+		Date:          aws.String("Mon, 5 Oct 2009 11:36:07 +0530"),
+		From:          aws.String(`"Gurpartap Singh" <gurpartap@patriots.in>`),
+		To:            []string{"<raj_deol2002in@yahoo.co.in>"},
+		ReplyTo:       aws.String("Support <support@patriots.in>"),
+		MsgID:         aws.String("<000301ca4581$ef9e57f0$cedb07d0$@in>"),
+		Subject:       aws.String("SMTP"),
+		FirstReceived: aws.String("from GP ([10.10.1.4]) by example.com"),
+		LastReply:     aws.String("250 OK id=1Mugho-0003Dg-Un"),
+		MailPath:      []string{"74.53.140.153", "10.10.1.4"},
+		UserAgent:     aws.String("Microsoft Office Outlook 12.0"),
+		TLS:           aws.Bool(false),
+		FUIDs:         []string{"Fel9gs4OtNEV6gUJZ5"},
+		IsWebmail:     aws.Bool(false),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.SMTP")
+	expectedEvent.AppendAnyIPAddress("10.10.1.4")
+	expectedEvent.AppendAnyIPAddress("74.53.140.153")
+	expectedEvent.AppendAnyEmails("gurpartap@patriots.in", "raj_deol2002in@yahoo.co.in", "support@patriots.in")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekSMTP(t, log, expectedEvent)
+}
+
+func TestZeekSMTPEmptySubject(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CmES5u32sYpV7JYN","id.orig_h":"10.10.1.4","id.orig_p":1470,"id.resp_h":"74.53.140.153","id.resp_p":25,"trans_depth":1,"mailfrom":"<>","rcptto":["Postmaster <postmaster@example.com>"],"subject":""}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekSMTP{
+		TS:         (*Time)(&expectedTime),
+		UID:        aws.String("CmES5u32sYpV7JYN"),
+		IDOrigH:    aws.String("10.10.1.4"),
+		IDOrigP:    aws.Uint16(1470),
+		IDRespH:    aws.String("74.53.140.153"),
+		IDRespP:    aws.Uint16(25),
+		TransDepth: aws.Uint64(1),
+		MailFrom:   aws.String("<>"),
+		RcptTo:     []string{"Postmaster <postmaster@example.com>"},
+		Subject:    aws.String(""),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.SMTP")
+	expectedEvent.AppendAnyIPAddress("10.10.1.4")
+	expectedEvent.AppendAnyIPAddress("74.53.140.153")
+	expectedEvent.AppendAnyEmails("postmaster@example.com")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekSMTP(t, log, expectedEvent)
+}
+
+func TestZeekSMTPMappedIPv6(t *testing.T) {
+	// Indicators are canonical while the fields keep the addresses logged by Zeek
+	checkZeekFixture(t, zeekFixture{
+		LogType: TypeZeekSMTP,
+		// nolint:lll
+		Log: `{"ts":1541001600.580233,"uid":"CmES5u32sYpV7JYN","id.orig_h":"10.10.1.4","id.orig_p":1470,"id.resp_h":"74.53.140.153","id.resp_p":25,"trans_depth":1,"path":["::ffff:74.53.140.153","::ffff:10.10.1.4"]}`,
+		Expect: `{
+			"ts": "2018-10-31 16:00:00.580233000",
+			"uid": "CmES5u32sYpV7JYN",
+			"id.orig_h": "10.10.1.4",
+			"id.orig_p": 1470,
+			"id.resp_h": "74.53.140.153",
+			"id.resp_p": 25,
+			"trans_depth": 1,
+			"path": ["::ffff:74.53.140.153", "::ffff:10.10.1.4"],
+			"p_log_type": "Zeek.SMTP",
+			"p_event_time": "2018-10-31 16:00:00.580233000",
+			"p_any_ip_addresses": ["10.10.1.4", "74.53.140.153"]
+		}`,
+	})
+}
+
+func TestZeekSMTPType(t *testing.T) {
+	parser := &ZeekSMTPParser{}
+	require.Equal(t, "Zeek.SMTP", parser.LogType())
+}
+
+func checkZeekSMTP(t *testing.T, log string, expectedEvent *ZeekSMTP) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekSMTPParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
@@ -0,0 +1,120 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekSNMP struct {
+	TS              *Time    `json:"ts,omitempty" validate:"required" description:"Timestamp of first packet belonging to the SNMP session."`
+	UID             *string  `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection."`
+	IDOrigH         *string  `json:"id.orig_h" panther:"ip" validate:"required" description:"The originator’s IP address."`
+	IDOrigP         *uint16  `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
+	IDRespH         *string  `json:"id.resp_h" panther:"ip" validate:"required" description:"The responder’s IP address."`
+	IDRespP         *uint16  `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
+	Duration        *float64 `json:"duration,omitempty" description:"The amount of time between the first packet belonging to the SNMP session and the latest one seen."`
+	DurationMillis  *float64 `json:"duration_ms,omitempty" description:"The duration of the SNMP session in milliseconds, only set if the parser has Milliseconds enabled."`
+	Version         *string  `json:"version,omitempty" description:"The version of SNMP being used."`
+	Community       *string  `json:"community,omitempty" description:"The community string of the first SNMP packet associated with the session. This is used as part of SNMP’s (v1 and v2c) administrative/security framework."`
+	GetRequests     *uint64  `json:"get_requests,omitempty" description:"The number of variable bindings in GetRequest/GetNextRequest PDUs seen for the session."`
+	GetBulkRequests *uint64  `json:"get_bulk_requests,omitempty" description:"The number of variable bindings in GetBulkRequest PDUs seen for the session."`
+	GetResponses    *uint64  `json:"get_responses,omitempty" description:"The number of variable bindings in GetResponse/Response PDUs seen for the session."`
+	SetRequests     *uint64  `json:"set_requests,omitempty" description:"The number of variable bindings in SetRequest PDUs seen for the session."`
+	DisplayString   *string  `json:"display_string,omitempty" description:"A system description of the SNMP responder endpoint."`
+	UpSince         *Time    `json:"up_since,omitempty" description:"The time at which the SNMP responder endpoint claims it’s been up since."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekSNMPParser parses zeek snmp logs
+type ZeekSNMPParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	// Milliseconds adds a field with the value in milliseconds next to each duration field in seconds
+	Milliseconds bool
+	decoder      logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekSNMPParser)(nil)
+
+func (p *ZeekSNMPParser) New() parsers.LogParser {
+	return &ZeekSNMPParser{
+		Strict:       p.Strict,
+		TimeOffset:   p.TimeOffset,
+		MinTime:      p.MinTime,
+		MaxTime:      p.MaxTime,
+		Indicators:   p.Indicators,
+		Milliseconds: p.Milliseconds,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekSNMPParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekSNMP := &ZeekSNMP{}
+
+	ok, err := p.decoder.Decode(log, pathSNMP, zeekSNMP, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	if p.Milliseconds {
+		zeekSNMP.DurationMillis = secondsToMillis(zeekSNMP.Duration)
+	}
+	zeekSNMP.updatePantherFields(p)
+	p.Indicators.apply(&zeekSNMP.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekSNMP); err != nil {
+		return nil, err
+	}
+
+	return zeekSNMP.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekSNMPParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekSNMPParser) LogType() string {
+	return TypeZeekSNMP
+}
+
+func (event *ZeekSNMP) updatePantherFields(p *ZeekSNMPParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+}
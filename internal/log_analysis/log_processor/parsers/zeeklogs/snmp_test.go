@@ -0,0 +1,103 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekSNMP(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CnKW1B4w9fpRa6Nkf2","id.orig_h":"10.10.10.23","id.orig_p":49636,"id.resp_h":"10.10.10.1","id.resp_p":161,"duration":0.014453,"version":"2c","community":"public","get_requests":3,"get_bulk_requests":0,"get_responses":3,"set_requests":0,"display_string":"Linux router 4.14.0 #1 SMP","up_since":1540396800.25}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedUpSince := time.Date(2018, 10, 24, 16, 0, 0, 250000000, time.UTC)
+	expectedEvent := &ZeekSNMP{
+		TS:              (*Time)(&expectedTime),
+		UID:             aws.String("CnKW1B4w9fpRa6Nkf2"),
+		IDOrigH:         aws.String("10.10.10.23"),
+		IDOrigP:         aws.Uint16(49636),
+		IDRespH:         aws.String("10.10.10.1"),
+		IDRespP:         aws.Uint16(161),
+		Duration:        aws.Float64(0.014453),
+		Version:         aws.String("2c"),
+		Community:       aws.String("public"),
+		GetRequests:     aws.Uint64(3),
+		GetBulkRequests: aws.Uint64(0),
+		GetResponses:    aws.Uint64(3),
+		SetRequests:     aws.Uint64(0),
+		DisplayString:   aws.String("Linux router 4.14.0 #1 SMP"),
+		UpSince:         (*Time)(&expectedUpSince),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.SNMP")
+	expectedEvent.AppendAnyIPAddress("10.10.10.23")
+	expectedEvent.AppendAnyIPAddress("10.10.10.1")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekSNMP(t, log, expectedEvent)
+}
+
+func TestZeekSNMPWithoutUpSince(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CnKW1B4w9fpRa6Nkf2","id.orig_h":"10.10.10.23","id.orig_p":49636,"id.resp_h":"10.10.10.1","id.resp_p":161,"duration":0.0,"version":"2c","community":"private","get_requests":1,"get_bulk_requests":0,"get_responses":0,"set_requests":0}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekSNMP{
+		TS:              (*Time)(&expectedTime),
+		UID:             aws.String("CnKW1B4w9fpRa6Nkf2"),
+		IDOrigH:         aws.String("10.10.10.23"),
+		IDOrigP:         aws.Uint16(49636),
+		IDRespH:         aws.String("10.10.10.1"),
+		IDRespP:         aws.Uint16(161),
+		Duration:        aws.Float64(0),
+		Version:         aws.String("2c"),
+		Community:       aws.String("private"),
+		GetRequests:     aws.Uint64(1),
+		GetBulkRequests: aws.Uint64(0),
+		GetResponses:    aws.Uint64(0),
+		SetRequests:     aws.Uint64(0),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.SNMP")
+	expectedEvent.AppendAnyIPAddress("10.10.10.23")
+	expectedEvent.AppendAnyIPAddress("10.10.10.1")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekSNMP(t, log, expectedEvent)
+}
+
+func TestZeekSNMPType(t *testing.T) {
+	parser := &ZeekSNMPParser{}
+	require.Equal(t, "Zeek.SNMP", parser.LogType())
+}
+
+func checkZeekSNMP(t *testing.T, log string, expectedEvent *ZeekSNMP) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekSNMPParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
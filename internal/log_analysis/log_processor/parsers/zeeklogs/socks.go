@@ -0,0 +1,124 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekSOCKS struct {
+	TS          *Time   `json:"ts,omitempty" validate:"required" description:"Time when the proxy connection was first detected."`
+	UID         *string `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection."`
+	IDOrigH     *string `json:"id.orig_h" panther:"ip" validate:"required" description:"The originator’s IP address."`
+	IDOrigP     *uint16 `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
+	IDRespH     *string `json:"id.resp_h" panther:"ip" validate:"required" description:"The responder’s IP address."`
+	IDRespP     *uint16 `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
+	Version     *uint64 `json:"version,omitempty" description:"Protocol version of SOCKS."`
+	User        *string `json:"user,omitempty" panther:"username" description:"Username used to request a login to the proxy."`
+	Password    *string `json:"password,omitempty" description:"Password used to request a login to the proxy."`
+	Status      *string `json:"status,omitempty" description:"Server status for the attempt at using the proxy."`
+	RequestHost *string `json:"request.host,omitempty" panther:"hostname" description:"Client requested SOCKS address. Usually an IP address but some clients send a domain name instead."`
+	RequestName *string `json:"request.name,omitempty" panther:"domain" description:"Client requested SOCKS domain name."`
+	RequestP    *uint16 `json:"request_p,omitempty" description:"Client requested port."`
+	BoundHost   *string `json:"bound.host,omitempty" panther:"hostname" description:"Server bound address. Usually an IP address but it can also be a domain name."`
+	BoundName   *string `json:"bound.name,omitempty" panther:"domain" description:"Server bound domain name."`
+	BoundP      *uint16 `json:"bound_p,omitempty" description:"Server bound port."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekSOCKSParser parses zeek socks logs
+type ZeekSOCKSParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekSOCKSParser)(nil)
+
+func (p *ZeekSOCKSParser) New() parsers.LogParser {
+	return &ZeekSOCKSParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekSOCKSParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekSOCKS := &ZeekSOCKS{}
+
+	ok, err := p.decoder.Decode(log, pathSOCKS, zeekSOCKS, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekSOCKS.updatePantherFields(p)
+	p.Indicators.apply(&zeekSOCKS.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekSOCKS); err != nil {
+		return nil, err
+	}
+
+	return zeekSOCKS.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekSOCKSParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekSOCKSParser) LogType() string {
+	return TypeZeekSOCKS
+}
+
+func (event *ZeekSOCKS) updatePantherFields(p *ZeekSOCKSParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+
+	if isSetPtr(event.User) {
+		event.AppendAnyUsernamePtrs(event.User)
+	}
+
+	// The requested and bound hosts are IP addresses unless the client used a domain name
+	appendHostnamePtr(&event.ZeekPantherLog, event.RequestHost)
+	appendHostnamePtr(&event.ZeekPantherLog, event.BoundHost)
+	appendDomainNamePtr(&event.ZeekPantherLog, event.RequestName)
+	appendDomainNamePtr(&event.ZeekPantherLog, event.BoundName)
+}
@@ -0,0 +1,105 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekSOCKS(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"Cmz4Cb4qCw1hGqYw1c","id.orig_h":"10.0.0.5","id.orig_p":35368,"id.resp_h":"10.0.0.9","id.resp_p":1080,"version":5,"user":"proxyuser","password":"hunter2","status":"succeeded","request.host":"203.0.113.7","request_p":443,"bound.host":"10.0.0.9","bound_p":40021}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekSOCKS{
+		TS:          (*Time)(&expectedTime),
+		UID:         aws.String("Cmz4Cb4qCw1hGqYw1c"),
+		IDOrigH:     aws.String("10.0.0.5"),
+		IDOrigP:     aws.Uint16(35368),
+		IDRespH:     aws.String("10.0.0.9"),
+		IDRespP:     aws.Uint16(1080),
+		Version:     aws.Uint64(5),
+		User:        aws.String("proxyuser"),
+		Password:    aws.String("hunter2"),
+		Status:      aws.String("succeeded"),
+		RequestHost: aws.String("203.0.113.7"),
+		RequestP:    aws.Uint16(443),
+		BoundHost:   aws.String("10.0.0.9"),
+		BoundP:      aws.Uint16(40021),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.SOCKS")
+	expectedEvent.AppendAnyIPAddress("10.0.0.5")
+	expectedEvent.AppendAnyIPAddress("10.0.0.9")
+	expectedEvent.AppendAnyIPAddress("203.0.113.7")
+	expectedEvent.AppendAnyUsernames("proxyuser")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekSOCKS(t, log, expectedEvent)
+}
+
+func TestZeekSOCKSRequestDomain(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"Cmz4Cb4qCw1hGqYw1c","id.orig_h":"10.0.0.5","id.orig_p":35368,"id.resp_h":"10.0.0.9","id.resp_p":1080,"version":5,"status":"succeeded","request.host":"Tunnel.Example.COM","request.name":"tunnel.example.com","request_p":443,"bound.host":"0.0.0.0","bound_p":0}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekSOCKS{
+		TS:          (*Time)(&expectedTime),
+		UID:         aws.String("Cmz4Cb4qCw1hGqYw1c"),
+		IDOrigH:     aws.String("10.0.0.5"),
+		IDOrigP:     aws.Uint16(35368),
+		IDRespH:     aws.String("10.0.0.9"),
+		IDRespP:     aws.Uint16(1080),
+		Version:     aws.Uint64(5),
+		Status:      aws.String("succeeded"),
+		RequestHost: aws.String("Tunnel.Example.COM"),
+		RequestName: aws.String("tunnel.example.com"),
+		RequestP:    aws.Uint16(443),
+		BoundHost:   aws.String("0.0.0.0"),
+		BoundP:      aws.Uint16(0),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.SOCKS")
+	expectedEvent.AppendAnyIPAddress("10.0.0.5")
+	expectedEvent.AppendAnyIPAddress("10.0.0.9")
+	expectedEvent.AppendAnyIPAddress("0.0.0.0")
+	expectedEvent.AppendAnyDomainNames("tunnel.example.com")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekSOCKS(t, log, expectedEvent)
+}
+
+func TestZeekSOCKSType(t *testing.T) {
+	parser := &ZeekSOCKSParser{}
+	require.Equal(t, "Zeek.SOCKS", parser.LogType())
+}
+
+func checkZeekSOCKS(t *testing.T, log string, expectedEvent *ZeekSOCKS) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekSOCKSParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
@@ -0,0 +1,109 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekSoftware struct {
+	TS              *Time   `json:"ts,omitempty" validate:"required" description:"The time at which the software was detected."`
+	Host            *string `json:"host,omitempty" panther:"ip" validate:"required" description:"The IP address detected running the software."`
+	HostP           *uint16 `json:"host_p,omitempty" description:"The port on which the software is running. Only sensible for server software."`
+	SoftwareType    *string `json:"software_type,omitempty" description:"The type of software detected (e.g. HTTP::SERVER)."`
+	Name            *string `json:"name,omitempty" description:"Name of the software (e.g. Apache)."`
+	VersionMajor    *uint64 `json:"version.major,omitempty" description:"Major version number."`
+	VersionMinor    *uint64 `json:"version.minor,omitempty" description:"Minor version number."`
+	VersionMinor2   *uint64 `json:"version.minor2,omitempty" description:"Minor subversion number."`
+	VersionMinor3   *uint64 `json:"version.minor3,omitempty" description:"Minor updates number."`
+	VersionAddl     *string `json:"version.addl,omitempty" description:"Additional version string (e.g. beta42)."`
+	UnparsedVersion *string `json:"unparsed_version,omitempty" description:"The full unparsed version string found because the version parsing doesn’t always work reliably in all cases and this acts as a fallback in the logs."`
+	URL             *string `json:"url,omitempty" description:"Most root URL where the software was discovered."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekSoftwareParser parses zeek software logs
+type ZeekSoftwareParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekSoftwareParser)(nil)
+
+func (p *ZeekSoftwareParser) New() parsers.LogParser {
+	return &ZeekSoftwareParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekSoftwareParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekSoftware := &ZeekSoftware{}
+
+	ok, err := p.decoder.Decode(log, pathSoftware, zeekSoftware, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekSoftware.updatePantherFields(p)
+	p.Indicators.apply(&zeekSoftware.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekSoftware); err != nil {
+		return nil, err
+	}
+
+	return zeekSoftware.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekSoftwareParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekSoftwareParser) LogType() string {
+	return TypeZeekSoftware
+}
+
+func (event *ZeekSoftware) updatePantherFields(p *ZeekSoftwareParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "host", event.Host)
+}
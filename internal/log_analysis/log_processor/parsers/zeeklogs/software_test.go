@@ -0,0 +1,88 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekSoftware(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"host":"192.168.1.104","host_p":80,"software_type":"HTTP::SERVER","name":"Apache","version.major":2,"version.minor":4,"version.minor2":41,"version.addl":"Ubuntu","unparsed_version":"Apache/2.4.41 (Ubuntu)"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekSoftware{
+		TS:              (*Time)(&expectedTime),
+		Host:            aws.String("192.168.1.104"),
+		HostP:           aws.Uint16(80),
+		SoftwareType:    aws.String("HTTP::SERVER"),
+		Name:            aws.String("Apache"),
+		VersionMajor:    aws.Uint64(2),
+		VersionMinor:    aws.Uint64(4),
+		VersionMinor2:   aws.Uint64(41),
+		VersionAddl:     aws.String("Ubuntu"),
+		UnparsedVersion: aws.String("Apache/2.4.41 (Ubuntu)"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Software")
+	expectedEvent.AppendAnyIPAddress("192.168.1.104")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekSoftware(t, log, expectedEvent)
+}
+
+func TestZeekSoftwareMajorVersionOnly(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"host":"::ffff:10.0.0.3","software_type":"HTTP::BROWSER","name":"curl","version.major":7,"unparsed_version":"curl/7"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekSoftware{
+		TS:              (*Time)(&expectedTime),
+		Host:            aws.String("::ffff:10.0.0.3"),
+		SoftwareType:    aws.String("HTTP::BROWSER"),
+		Name:            aws.String("curl"),
+		VersionMajor:    aws.Uint64(7),
+		UnparsedVersion: aws.String("curl/7"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Software")
+	expectedEvent.AppendAnyIPAddress("10.0.0.3")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekSoftware(t, log, expectedEvent)
+}
+
+func TestZeekSoftwareType(t *testing.T) {
+	parser := &ZeekSoftwareParser{}
+	require.Equal(t, "Zeek.Software", parser.LogType())
+}
+
+func checkZeekSoftware(t *testing.T, log string, expectedEvent *ZeekSoftware) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekSoftwareParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
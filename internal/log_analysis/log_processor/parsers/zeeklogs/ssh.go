@@ -0,0 +1,116 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekSSH struct {
+	TS             *Time   `json:"ts,omitempty" validate:"required" description:"Time when the SSH connection began."`
+	UID            *string `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection."`
+	IDOrigH        *string `json:"id.orig_h" panther:"ip" validate:"required" description:"The originator’s IP address."`
+	IDOrigP        *uint16 `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
+	IDRespH        *string `json:"id.resp_h" panther:"ip" validate:"required" description:"The responder’s IP address."`
+	IDRespP        *uint16 `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
+	Version        *uint64 `json:"version,omitempty" description:"SSH major version (1 or 2)."`
+	AuthSuccess    *bool   `json:"auth_success,omitempty" description:"Authentication result (T=success, F=failure, unset=unknown)."`
+	AuthAttempts   *uint64 `json:"auth_attempts,omitempty" description:"The number of authentication attempts we observed. There’s always at least one, since some servers might support no authentication at all. It’s important to note that not all of these are failures, since some servers require two-factor auth (e.g. password AND pubkey)."`
+	Direction      *string `json:"direction,omitempty" description:"Direction of the connection. If the client was a local host logging into an external host, this would be OUTBOUND. INBOUND would be set for the opposite situation."`
+	Client         *string `json:"client,omitempty" description:"The client’s version string."`
+	Server         *string `json:"server,omitempty" description:"The server’s version string."`
+	CipherAlg      *string `json:"cipher_alg,omitempty" description:"The encryption algorithm in use."`
+	MACAlg         *string `json:"mac_alg,omitempty" description:"The signing (MAC) algorithm in use."`
+	CompressionAlg *string `json:"compression_alg,omitempty" description:"The compression algorithm in use."`
+	KexAlg         *string `json:"kex_alg,omitempty" description:"The key exchange algorithm in use."`
+	HostKeyAlg     *string `json:"host_key_alg,omitempty" description:"The server host key’s algorithm."`
+	HostKey        *string `json:"host_key,omitempty" description:"The server’s key fingerprint."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekSSHParser parses zeek ssh logs
+type ZeekSSHParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekSSHParser)(nil)
+
+func (p *ZeekSSHParser) New() parsers.LogParser {
+	return &ZeekSSHParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekSSHParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekSSH := &ZeekSSH{}
+
+	ok, err := p.decoder.Decode(log, pathSSH, zeekSSH, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekSSH.updatePantherFields(p)
+	p.Indicators.apply(&zeekSSH.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekSSH); err != nil {
+		return nil, err
+	}
+
+	return zeekSSH.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekSSHParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekSSHParser) LogType() string {
+	return TypeZeekSSH
+}
+
+func (event *ZeekSSH) updatePantherFields(p *ZeekSSHParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+}
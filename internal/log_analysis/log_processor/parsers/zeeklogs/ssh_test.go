@@ -0,0 +1,108 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekSSH(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CBgVDu2mn1tvQ1dYSh","id.orig_h":"172.16.2.16","id.orig_p":55246,"id.resp_h":"172.16.0.10","id.resp_p":22,"version":2,"auth_success":false,"auth_attempts":3,"direction":"INBOUND","client":"SSH-2.0-OpenSSH_7.4","server":"SSH-2.0-OpenSSH_7.6p1 Ubuntu-4ubuntu0.3","cipher_alg":"chacha20-poly1305@openssh.com","mac_alg":"umac-64-etm@openssh.com","compression_alg":"none","kex_alg":"curve25519-sha256","host_key_alg":"ecdsa-sha2-nistp256","host_key":"c7:a5:6d:1b:f3:3e:b2:7a:4e:6e:0d:47:c6:06:b8:0e"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekSSH{
+		TS:             (*Time)(&expectedTime),
+		UID:            aws.String("CBgVDu2mn1tvQ1dYSh"),
+		IDOrigH:        aws.String("172.16.2.16"),
+		IDOrigP:        aws.Uint16(55246),
+		IDRespH:        aws.String("172.16.0.10"),
+		IDRespP:        aws.Uint16(22),
+		Version:        aws.Uint64(2),
+		AuthSuccess:    aws.Bool(false),
+		AuthAttempts:   aws.Uint64(3),
+		Direction:      aws.String("INBOUND"),
+		Client:         aws.String("SSH-2.0-OpenSSH_7.4"),
+		Server:         aws.String("SSH-2.0-OpenSSH_7.6p1 Ubuntu-4ubuntu0.3"),
+		CipherAlg:      aws.String("chacha20-poly1305@openssh.com"),
+		MACAlg:         aws.String("umac-64-etm@openssh.com"),
+		CompressionAlg: aws.String("none"),
+		KexAlg:         aws.String("curve25519-sha256"),
+		HostKeyAlg:     aws.String("ecdsa-sha2-nistp256"),
+		HostKey:        aws.String("c7:a5:6d:1b:f3:3e:b2:7a:4e:6e:0d:47:c6:06:b8:0e"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.SSH")
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDOrigH)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDRespH)
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekSSH(t, log, expectedEvent)
+}
+
+func TestZeekSSHUnsetAuthSuccess(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CBgVDu2mn1tvQ1dYSh","id.orig_h":"172.16.2.16","id.orig_p":55246,"id.resp_h":"172.16.0.10","id.resp_p":22,"version":2,"auth_attempts":0}`
+	logs, err := (&ZeekSSHParser{}).Parse(log)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	require.Nil(t, logs[0].Event().(*ZeekSSH).AuthSuccess)
+
+	parser := (&ZeekSSHParser{}).New()
+	for _, line := range []string{
+		`#separator \x09`,
+		"#path\tssh",
+		"#fields\tts\tuid\tid.orig_h\tid.orig_p\tid.resp_h\tid.resp_p\tversion\tauth_success\tauth_attempts",
+	} {
+		_, err := parser.Parse(line)
+		require.NoError(t, err)
+	}
+	for _, row := range []struct {
+		Value  string
+		Expect *bool
+	}{
+		{"-", nil},
+		{"F", aws.Bool(false)},
+		{"T", aws.Bool(true)},
+	} {
+		logs, err := parser.Parse("1541001600.580233\tCBgVDu2mn1tvQ1dYSh\t172.16.2.16\t55246\t172.16.0.10\t22\t2\t" + row.Value + "\t1")
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		require.Equal(t, row.Expect, logs[0].Event().(*ZeekSSH).AuthSuccess, row.Value)
+	}
+}
+
+func TestZeekSSHType(t *testing.T) {
+	parser := &ZeekSSHParser{}
+	require.Equal(t, "Zeek.SSH", parser.LogType())
+}
+
+func checkZeekSSH(t *testing.T, log string, expectedEvent *ZeekSSH) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekSSHParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
@@ -0,0 +1,128 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekSSL struct {
+	TS                   *Time   `json:"ts,omitempty" validate:"required" description:"Time when the SSL connection was first detected."`
+	UID                  *string `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection."`
+	IDOrigH              *string `json:"id.orig_h" panther:"ip" validate:"required" description:"The originator’s IP address."`
+	IDOrigP              *uint16 `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
+	IDRespH              *string `json:"id.resp_h" panther:"ip" validate:"required" description:"The responder’s IP address."`
+	IDRespP              *uint16 `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
+	Version              *string `json:"version,omitempty" description:"SSL/TLS version that the server chose."`
+	Cipher               *string `json:"cipher,omitempty" description:"SSL/TLS cipher suite that the server chose."`
+	Curve                *string `json:"curve,omitempty" description:"Elliptic curve the server chose when using ECDH/ECDHE."`
+	ServerName           *string `json:"server_name,omitempty" panther:"domain" description:"Value of the Server Name Indicator SSL/TLS extension. It indicates the server name that the client was requesting."`
+	Resumed              *bool   `json:"resumed,omitempty" description:"Flag to indicate if the session was resumed reusing the key material exchanged in an earlier connection."`
+	LastAlert            *string `json:"last_alert,omitempty" description:"Last alert that was seen during the connection."`
+	NextProtocol         *string `json:"next_protocol,omitempty" description:"Next protocol the server chose using the application layer next protocol extension, if present."`
+	Established          *bool   `json:"established,omitempty" description:"Flag to indicate if this ssl session has been established successfully, or if it was aborted during the handshake. Older Zeek versions do not log this field."`
+	SSLHistory           *string `json:"ssl_history,omitempty" description:"SSL history showing which types of packets were received in which order. Uppercase letters indicate the client and lowercase the server."`
+	CertChainFUIDs       Set     `json:"cert_chain_fuids,omitempty" description:"An ordered vector of all certificate file unique IDs for the certificates offered by the server."`
+	ClientCertChainFUIDs Set     `json:"client_cert_chain_fuids,omitempty" description:"An ordered vector of all certificate file unique IDs for the certificates offered by the client."`
+	Subject              *string `json:"subject,omitempty" description:"Subject of the X.509 certificate offered by the server."`
+	Issuer               *string `json:"issuer,omitempty" description:"Subject of the signer of the X.509 certificate offered by the server."`
+	ClientSubject        *string `json:"client_subject,omitempty" description:"Subject of the X.509 certificate offered by the client."`
+	ClientIssuer         *string `json:"client_issuer,omitempty" description:"Subject of the signer of the X.509 certificate offered by the client."`
+	ValidationStatus     *string `json:"validation_status,omitempty" description:"Result of certificate validation for this connection."`
+	JA3                  *string `json:"ja3,omitempty" description:"The JA3 fingerprint (MD5 hex) of the client hello."`
+	JA3S                 *string `json:"ja3s,omitempty" description:"The JA3S fingerprint (MD5 hex) of the server hello."`
+	Direction            *string `json:"direction,omitempty" description:"Direction of the connection relative to the local networks of the parser (inbound, outbound, internal or external)."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekSSLParser parses zeek ssl logs
+type ZeekSSLParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	// LocalNetworks are the internal networks used to set the direction of connections, RFC 1918 ranges by default
+	LocalNetworks Networks
+	decoder       logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekSSLParser)(nil)
+
+func (p *ZeekSSLParser) New() parsers.LogParser {
+	return &ZeekSSLParser{
+		Strict:        p.Strict,
+		TimeOffset:    p.TimeOffset,
+		MinTime:       p.MinTime,
+		MaxTime:       p.MaxTime,
+		Indicators:    p.Indicators,
+		LocalNetworks: p.LocalNetworks,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekSSLParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekSSL := &ZeekSSL{}
+
+	ok, err := p.decoder.Decode(log, pathSSL, zeekSSL, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekSSL.Direction = connDirection(p.LocalNetworks, zeekSSL.IDOrigH, zeekSSL.IDRespH)
+	zeekSSL.updatePantherFields(p)
+	p.Indicators.apply(&zeekSSL.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekSSL); err != nil {
+		return nil, err
+	}
+
+	return zeekSSL.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekSSLParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekSSLParser) LogType() string {
+	return TypeZeekSSL
+}
+
+func (event *ZeekSSL) updatePantherFields(p *ZeekSSLParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+	appendDomainNamePtr(&event.ZeekPantherLog, event.ServerName)
+}
@@ -0,0 +1,108 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekSSL(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"C3zRsb2bhMLFBOaz9b","id.orig_h":"172.16.2.16","id.orig_p":52856,"id.resp_h":"52.94.233.129","id.resp_p":443,"version":"TLSv12","cipher":"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256","curve":"secp256r1","server_name":"sqs.us-west-2.amazonaws.com","resumed":false,"established":true,"ssl_history":"CsxknGIi","cert_chain_fuids":["FZevty3x5kXZvAPCG2","FeWxJf2mKkn3u4cma6"],"client_cert_chain_fuids":[],"subject":"CN=sqs.us-west-2.amazonaws.com","issuer":"CN=Amazon,OU=Server CA 1B,O=Amazon,C=US","validation_status":"ok","ja3":"e7d705a3286e19ea42f587b344ee6865","ja3s":"0debd3853f330c574b05e0b6d882dc27"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekSSL{
+		TS:                   (*Time)(&expectedTime),
+		UID:                  aws.String("C3zRsb2bhMLFBOaz9b"),
+		IDOrigH:              aws.String("172.16.2.16"),
+		IDOrigP:              aws.Uint16(52856),
+		IDRespH:              aws.String("52.94.233.129"),
+		IDRespP:              aws.Uint16(443),
+		Version:              aws.String("TLSv12"),
+		Cipher:               aws.String("TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"),
+		Curve:                aws.String("secp256r1"),
+		ServerName:           aws.String("sqs.us-west-2.amazonaws.com"),
+		Resumed:              aws.Bool(false),
+		Established:          aws.Bool(true),
+		SSLHistory:           aws.String("CsxknGIi"),
+		CertChainFUIDs:       []string{"FZevty3x5kXZvAPCG2", "FeWxJf2mKkn3u4cma6"},
+		ClientCertChainFUIDs: []string{},
+		Subject:              aws.String("CN=sqs.us-west-2.amazonaws.com"),
+		Issuer:               aws.String("CN=Amazon,OU=Server CA 1B,O=Amazon,C=US"),
+		ValidationStatus:     aws.String("ok"),
+		JA3:                  aws.String("e7d705a3286e19ea42f587b344ee6865"),
+		JA3S:                 aws.String("0debd3853f330c574b05e0b6d882dc27"),
+		Direction:            aws.String("outbound"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.SSL")
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDOrigH)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDRespH)
+	expectedEvent.AppendAnyDomainNamePtrs(expectedEvent.ServerName)
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekSSL(t, log, expectedEvent)
+}
+
+func TestZeekSSLWithoutEstablished(t *testing.T) {
+	// Older Zeek versions do not log the `established` field
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"C3zRsb2bhMLFBOaz9b","id.orig_h":"172.16.2.16","id.orig_p":52856,"id.resp_h":"52.94.233.129","id.resp_p":443,"version":"TLSv10","cipher":"TLS_RSA_WITH_RC4_128_SHA","resumed":true,"ja3":"de350869b8c85de67a350c8d186f11e6"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekSSL{
+		TS:        (*Time)(&expectedTime),
+		UID:       aws.String("C3zRsb2bhMLFBOaz9b"),
+		IDOrigH:   aws.String("172.16.2.16"),
+		IDOrigP:   aws.Uint16(52856),
+		IDRespH:   aws.String("52.94.233.129"),
+		IDRespP:   aws.Uint16(443),
+		Version:   aws.String("TLSv10"),
+		Cipher:    aws.String("TLS_RSA_WITH_RC4_128_SHA"),
+		Resumed:   aws.Bool(true),
+		JA3:       aws.String("de350869b8c85de67a350c8d186f11e6"),
+		Direction: aws.String("outbound"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.SSL")
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDOrigH)
+	expectedEvent.AppendAnyIPAddressPtr(expectedEvent.IDRespH)
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekSSL(t, log, expectedEvent)
+}
+
+func TestZeekSSLType(t *testing.T) {
+	parser := &ZeekSSLParser{}
+	require.Equal(t, "Zeek.SSL", parser.LogType())
+}
+
+func checkZeekSSL(t *testing.T, log string, expectedEvent *ZeekSSL) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekSSLParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
@@ -0,0 +1,102 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekStats struct {
+	TS          *Time   `json:"ts,omitempty" validate:"required" description:"Timestamp for the measurement."`
+	Peer        *string `json:"peer,omitempty" description:"Peer that generated this log. Mostly for clusters."`
+	Mem         *uint64 `json:"mem,omitempty" description:"Amount of memory currently in use in MB."`
+	PktsProc    *uint64 `json:"pkts_proc,omitempty" description:"Number of packets processed since the last stats interval."`
+	PktsDropped *uint64 `json:"pkts_dropped,omitempty" description:"Number of packets dropped since the last stats interval if reading live traffic."`
+	PktsLink    *uint64 `json:"pkts_link,omitempty" description:"Number of packets seen on the link since the last stats interval if reading live traffic."`
+	EventsProc  *uint64 `json:"events_proc,omitempty" description:"Number of events processed since the last stats interval."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekStatsParser parses zeek stats logs
+type ZeekStatsParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekStatsParser)(nil)
+
+func (p *ZeekStatsParser) New() parsers.LogParser {
+	return &ZeekStatsParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekStatsParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekStats := &ZeekStats{}
+
+	ok, err := p.decoder.Decode(log, pathStats, zeekStats, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekStats.updatePantherFields(p)
+	p.Indicators.apply(&zeekStats.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekStats); err != nil {
+		return nil, err
+	}
+
+	return zeekStats.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekStatsParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekStatsParser) LogType() string {
+	return TypeZeekStats
+}
+
+func (event *ZeekStats) updatePantherFields(p *ZeekStatsParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+}
@@ -0,0 +1,67 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestZeekStats(t *testing.T) {
+	checkZeekFixture(t, zeekFixture{
+		LogType: TypeZeekStats,
+		// nolint:lll
+		Log: `{"ts":1541001600.580233,"peer":"worker-1-1","mem":412,"pkts_proc":1834112,"bytes_recv":1394857351,"pkts_dropped":0,"pkts_link":1834127,"pkt_lag":0.001142,"events_proc":2615593,"events_queued":2615640}`,
+		Expect: `{
+			"ts": "2018-10-31 16:00:00.580233000",
+			"peer": "worker-1-1",
+			"mem": 412,
+			"pkts_proc": 1834112,
+			"pkts_dropped": 0,
+			"pkts_link": 1834127,
+			"events_proc": 2615593,
+			"p_log_type": "Zeek.Stats",
+			"p_event_time": "2018-10-31 16:00:00.580233000"
+		}`,
+		Golden: "stats",
+	})
+}
+
+func TestZeekStatsOffline(t *testing.T) {
+	// Packet drops are only reported when reading live traffic
+	checkZeekFixture(t, zeekFixture{
+		LogType: TypeZeekStats,
+		Log:     `{"ts":1541001600.580233,"peer":"zeek","mem":64,"pkts_proc":1024,"events_proc":4096}`,
+		Expect: `{
+			"ts": "2018-10-31 16:00:00.580233000",
+			"peer": "zeek",
+			"mem": 64,
+			"pkts_proc": 1024,
+			"events_proc": 4096,
+			"p_log_type": "Zeek.Stats",
+			"p_event_time": "2018-10-31 16:00:00.580233000"
+		}`,
+	})
+}
+
+func TestZeekStatsType(t *testing.T) {
+	parser := &ZeekStatsParser{}
+	require.Equal(t, "Zeek.Stats", parser.LogType())
+}
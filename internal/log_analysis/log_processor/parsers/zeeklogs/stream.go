@@ -0,0 +1,411 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// gzipMagic is the prefix of every gzip member (RFC 1952)
+const gzipMagic = "\x1f\x8b"
+
+// StreamConfig holds the options for parsing Zeek log streams
+type StreamConfig struct {
+	// Gzip decompresses streams that start with the gzip magic bytes before splitting them to lines.
+	// It is off by default so that callers that decompress logs themselves never have their input inspected.
+	Gzip bool
+	// Input selects how the stream is split to log lines, InputLines by default.
+	Input InputMode
+	// MaxLineLength is the maximum length of a line in bytes, DefaultMaxLineLength if zero.
+	// Longer lines are skipped without being buffered as a whole and reported as a failed line of the parser.
+	// In InputJSONArray mode it is the maximum length of an element as it appears in the stream.
+	MaxLineLength int
+}
+
+// DefaultMaxLineLength is large enough for HTTP logs with very long URIs
+const DefaultMaxLineLength = 4 * 1024 * 1024
+
+// LineTooLongError is the error reported for a line that exceeds the maximum line length of a stream
+type LineTooLongError struct {
+	// Length is the length of the line in bytes, without the line terminator
+	Length int
+	// MaxLength is the maximum line length of the stream
+	MaxLength int
+}
+
+func (e *LineTooLongError) Error() string {
+	return fmt.Sprintf("zeek log line of %d bytes exceeds the maximum line length of %d bytes", e.Length, e.MaxLength)
+}
+
+// InputMode is the format of the log lines of a Zeek log stream
+type InputMode int
+
+const (
+	// InputLines reads one JSON object or TSV row per line
+	InputLines InputMode = iota
+	// InputJSONArray reads the elements of a single top-level JSON array.
+	// Elements are decoded one at a time so the array is never loaded in memory as a whole.
+	InputJSONArray
+)
+
+// ParseStream parses all lines of a Zeek log stream and returns the results in order.
+// Lines that fail to parse, including lines longer than the maximum line length, are skipped and reported together
+// with the results of the other lines in a LineErrors error.
+// Parsing only stops if the stream cannot be read or decompressed. The config is optional.
+func ParseStream(input io.Reader, parser parsers.Interface, config *StreamConfig) ([]*parsers.Result, error) {
+	var results []*parsers.Result
+	var lineErrors LineErrors
+	iter := NewLineIterator(input, parser, config)
+	for iter.Next() {
+		if err := iter.LineErr(); err != nil {
+			lineErrors = append(lineErrors, err)
+			continue
+		}
+		results = append(results, iter.Results()...)
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	if len(lineErrors) > 0 {
+		return results, lineErrors
+	}
+	return results, nil
+}
+
+// LineErrors are the errors of the lines of a stream that failed to parse, in the order of the lines
+type LineErrors []error
+
+func (e LineErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	return fmt.Sprintf("%d zeek log lines failed to parse, first error: %s", len(e), e[0])
+}
+
+// LineIterator parses the lines of a Zeek log stream one at a time.
+// Lines that fail to parse do not stop the iteration, so that callers can report them and continue.
+// Empty lines are skipped. Lines longer than the maximum line length are not passed to the parser, they are counted
+// by the parsers created by the factories of Zeek log types and by MultiParser and reported as a *LineError that wraps
+// a *LineTooLongError. Other parsers report them with a bare *LineTooLongError.
+// In InputJSONArray mode each element of the array is parsed as a line.
+type LineIterator struct {
+	parser  parsers.Interface
+	stream  *bufio.Reader
+	results []*parsers.Result
+	lineErr error
+	err     error
+	done    bool
+	// line is the buffer of the current line
+	line          []byte
+	maxLineLength int
+	// jsonArray, inArray and numElements are the state of InputJSONArray streams
+	jsonArray   bool
+	inArray     bool
+	numElements int
+	element     bytes.Buffer
+}
+
+// NewLineIterator creates an iterator over the lines of a Zeek log stream.
+// The parser should be created once per stream so that it keeps track of the header directives of TSV logs.
+// The config is optional.
+func NewLineIterator(input io.Reader, parser parsers.Interface, config *StreamConfig) *LineIterator {
+	stream, err := openStream(input, config)
+	it := &LineIterator{
+		parser:        parser,
+		stream:        stream,
+		err:           err,
+		done:          err != nil,
+		maxLineLength: DefaultMaxLineLength,
+	}
+	if config != nil && config.MaxLineLength > 0 {
+		it.maxLineLength = config.MaxLineLength
+	}
+	if err == nil && config != nil && config.Input == InputJSONArray {
+		it.jsonArray = true
+	}
+	return it
+}
+
+// Next parses the next line of the stream.
+// It returns false once the end of the stream is reached or if the stream cannot be read.
+func (it *LineIterator) Next() bool {
+	it.results, it.lineErr = nil, nil
+	if it.jsonArray {
+		return it.nextElement()
+	}
+	for !it.done {
+		line, tooLong, err := it.readLine()
+		if err != nil {
+			it.done = true
+			if err != io.EOF {
+				it.err = err
+				return false
+			}
+		}
+		if tooLong != nil {
+			it.lineErr = it.skipLine(tooLong)
+			return true
+		}
+		if line != "" {
+			it.results, it.lineErr = it.parser.ParseLog(line)
+			return true
+		}
+	}
+	return false
+}
+
+// lineSkipper is implemented by the parsers that count the lines of a stream
+type lineSkipper interface {
+	skipLine(err error) *LineError
+}
+
+// skipLine reports a line that is not passed to the parser through the parser if it counts lines,
+// so that the line numbers of the errors of the following lines match the lines of the stream.
+func (it *LineIterator) skipLine(err error) error {
+	if p, ok := it.parser.(lineSkipper); ok {
+		return p.skipLine(err)
+	}
+	return err
+}
+
+// readLine reads the next line of the stream without the line terminator.
+// The rest of a line that exceeds the maximum line length is discarded as it is read.
+func (it *LineIterator) readLine() (string, *LineTooLongError, error) {
+	it.line = it.line[:0]
+	length := 0
+	for {
+		chunk, err := it.stream.ReadSlice('\n')
+		last := err != bufio.ErrBufferFull
+		if last {
+			chunk = bytes.TrimRight(chunk, "\r\n")
+		}
+		length += len(chunk)
+		if length > it.maxLineLength {
+			it.line = it.line[:0]
+		} else {
+			it.line = append(it.line, chunk...)
+		}
+		if !last {
+			continue
+		}
+		if length > it.maxLineLength {
+			return "", &LineTooLongError{
+				Length:    length,
+				MaxLength: it.maxLineLength,
+			}, err
+		}
+		return string(it.line), nil, err
+	}
+}
+
+// nextElement parses the next element of a JSON array stream.
+// Elements are compacted to a single line so that they are parsed the same way as JSON lines.
+func (it *LineIterator) nextElement() bool {
+	if it.done {
+		return false
+	}
+	if !it.inArray {
+		c, err := it.readNonSpace()
+		if err == io.EOF {
+			// An empty stream has no events
+			it.done = true
+			return false
+		}
+		if err != nil || c != '[' {
+			it.done, it.err = true, errors.New("zeek log stream is not a JSON array")
+			return false
+		}
+		it.inArray = true
+	}
+	c, err := it.readNonSpace()
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		it.done, it.err = true, errors.Wrap(err, "failed to read zeek JSON array stream")
+		return false
+	}
+	if c == ']' {
+		it.done = true
+		if _, err := it.readNonSpace(); err != io.EOF {
+			it.err = errors.New("unexpected data after zeek JSON array stream")
+		}
+		return false
+	}
+	if it.numElements > 0 {
+		if c != ',' {
+			it.done, it.err = true, errors.New("failed to read zeek JSON array stream: expected ',' or ']'")
+			return false
+		}
+	} else if err := it.stream.UnreadByte(); err != nil {
+		it.done, it.err = true, errors.Wrap(err, "failed to read zeek JSON array stream")
+		return false
+	}
+	it.numElements++
+	length, err := it.readElement()
+	if err != nil {
+		it.done, it.err = true, errors.Wrap(err, "failed to read zeek JSON array stream")
+		return false
+	}
+	if length > it.maxLineLength {
+		it.lineErr = it.skipLine(&LineTooLongError{
+			Length:    length,
+			MaxLength: it.maxLineLength,
+		})
+		return true
+	}
+	it.element.Reset()
+	if err := json.Compact(&it.element, it.line); err != nil {
+		it.done, it.err = true, errors.Wrap(err, "failed to read zeek JSON array stream")
+		return false
+	}
+	it.results, it.lineErr = it.parser.ParseLog(it.element.String())
+	return true
+}
+
+// readElement reads the next JSON value of an array stream to the line buffer and returns its length.
+// The value is only scanned for its end so that values that exceed the maximum line length are discarded as they
+// are read, it is validated when it is compacted.
+func (it *LineIterator) readElement() (int, error) {
+	it.line = it.line[:0]
+	length, depth, inString, escaped := 0, 0, false, false
+	for {
+		c, err := it.stream.ReadByte()
+		if err == io.EOF {
+			return length, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return length, err
+		}
+		if length == 0 && isJSONSpace(c) {
+			continue
+		}
+		if !inString && depth == 0 && length > 0 && (c == ',' || c == ']' || isJSONSpace(c)) {
+			// The end of a number or literal
+			return length, it.stream.UnreadByte()
+		}
+		length++
+		if length <= it.maxLineLength {
+			it.line = append(it.line, c)
+		}
+		switch {
+		case escaped:
+			escaped = false
+		case inString:
+			escaped = c == '\\'
+			inString = c != '"'
+		case c == '"':
+			inString = true
+		case c == '{' || c == '[':
+			depth++
+		case c == '}' || c == ']':
+			depth--
+		case c == ',' && depth == 0:
+			return length, errors.New("expected a JSON value")
+		}
+		if depth < 0 {
+			return length, errors.New("unexpected end of JSON value")
+		}
+		if depth == 0 && !inString && (c == '}' || c == ']' || c == '"') {
+			return length, nil
+		}
+	}
+}
+
+// readNonSpace reads the next byte of the stream that is not JSON whitespace
+func (it *LineIterator) readNonSpace() (byte, error) {
+	for {
+		c, err := it.stream.ReadByte()
+		if err != nil || !isJSONSpace(c) {
+			return c, err
+		}
+	}
+}
+
+func isJSONSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// Results returns the results of the current line
+func (it *LineIterator) Results() []*parsers.Result {
+	return it.results
+}
+
+// LineErr returns the error of the parser for the current line.
+// The parsers created by the factories of Zeek log types report a *LineError.
+func (it *LineIterator) LineErr() error {
+	return it.lineErr
+}
+
+// Err returns the error that stopped the iteration before the end of the stream
+func (it *LineIterator) Err() error {
+	return it.err
+}
+
+// openStream wraps the input stream in a decompressing reader if needed
+func openStream(input io.Reader, config *StreamConfig) (*bufio.Reader, error) {
+	r := bufio.NewReader(input)
+	if config == nil || !config.Gzip {
+		return r, nil
+	}
+	prefix, err := r.Peek(len(gzipMagic))
+	if err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "failed to read zeek log stream")
+	}
+	if string(prefix) != gzipMagic {
+		return r, nil
+	}
+	// Readers are in multistream mode by default so the members of rotated logs are read as one stream
+	z, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decompress zeek log stream")
+	}
+	return bufio.NewReader(&gzipStream{z}), nil
+}
+
+// gzipStream reports read errors of the gzip stream as decompression errors
+type gzipStream struct {
+	r *gzip.Reader
+}
+
+func (s *gzipStream) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	if err != nil && err != io.EOF {
+		err = errors.Wrap(err, "failed to decompress zeek log stream")
+	}
+	return n, err
+}
+
+// parseString adapts the results of a parser to the results of parsers.Interface
+func parseString(parser parsers.LogParser, line string) ([]*parsers.Result, error) {
+	logs, err := parser.Parse(line)
+	if err != nil {
+		return nil, err
+	}
+	return parsers.ToResults(logs, nil)
+}
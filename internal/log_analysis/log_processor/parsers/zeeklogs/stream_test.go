@@ -0,0 +1,389 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+// nolint:lll
+var streamDNSLines = []string{
+	`{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq6","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","query":"www.example.com"}`,
+	`{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq7","id.orig_h":"172.16.2.16","id.orig_p":43721,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","query":"www.example.org"}`,
+	`{"ts":1541001600.580233,"uid":"CpR9AY39cUCZ0t5qq8","id.orig_h":"172.16.2.16","id.orig_p":43722,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","query":"www.example.net"}`,
+}
+
+func gzipLines(t *testing.T, lines ...string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(strings.Join(lines, "\n") + "\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func newStreamDNSParser(t *testing.T) *Adapter {
+	parser, err := adapterFactory(&ZeekDNSParser{}).NewParser(nil)
+	require.NoError(t, err)
+	return parser.(*Adapter)
+}
+
+func TestParseStreamGzip(t *testing.T) {
+	// Rotated logs can have multiple gzip members
+	var input []byte
+	input = append(input, gzipLines(t, streamDNSLines[:2]...)...)
+	input = append(input, gzipLines(t, streamDNSLines[2:]...)...)
+
+	results, err := ParseStream(bytes.NewReader(input), newStreamDNSParser(t), &StreamConfig{Gzip: true})
+	require.NoError(t, err)
+	require.Len(t, results, len(streamDNSLines))
+	for i, result := range results {
+		require.Equal(t, TypeZeekDNS, result.PantherLogType)
+		event := result.Event.(*ZeekDNS)
+		require.Equal(t, []string{"www.example.com", "www.example.org", "www.example.net"}[i], *event.Query)
+	}
+}
+
+func TestParseStreamGzipDisabled(t *testing.T) {
+	input := gzipLines(t, streamDNSLines...)
+	_, err := ParseStream(bytes.NewReader(input), newStreamDNSParser(t), nil)
+	require.Error(t, err)
+
+	results, err := ParseStream(strings.NewReader(strings.Join(streamDNSLines, "\r\n")), newStreamDNSParser(t), nil)
+	require.NoError(t, err)
+	require.Len(t, results, len(streamDNSLines))
+}
+
+func TestParseStreamUncompressed(t *testing.T) {
+	input := strings.Join(streamDNSLines, "\n") + "\n\n"
+	results, err := ParseStream(strings.NewReader(input), newStreamDNSParser(t), &StreamConfig{Gzip: true})
+	require.NoError(t, err)
+	require.Len(t, results, len(streamDNSLines))
+}
+
+func TestParseStreamGzipCorrupt(t *testing.T) {
+	input := gzipLines(t, streamDNSLines...)
+	// Truncate the stream before the gzip trailer
+	input = input[:len(input)-4]
+	_, err := ParseStream(bytes.NewReader(input), newStreamDNSParser(t), &StreamConfig{Gzip: true})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to decompress zeek log stream")
+
+	_, err = ParseStream(strings.NewReader(gzipMagic+"foo"), newStreamDNSParser(t), &StreamConfig{Gzip: true})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to decompress zeek log stream")
+}
+
+func TestParseStreamJSONArray(t *testing.T) {
+	input := "[\n  " + strings.Join(streamDNSLines, ",\n  ") + "\n]\n"
+	config := &StreamConfig{Input: InputJSONArray}
+	parser, err := adapterFactory(&ZeekDNSParser{}).NewParser(&AdapterConfig{
+		KeepRawLine: true,
+	})
+	require.NoError(t, err)
+	results, err := ParseStream(strings.NewReader(input), parser, config)
+	require.NoError(t, err)
+	require.Len(t, results, len(streamDNSLines))
+	for i, result := range results {
+		// Elements are parsed the same way as JSON lines
+		event := result.Event.(*ZeekDNS)
+		require.Equal(t, []string{"www.example.com", "www.example.org", "www.example.net"}[i], *event.Query)
+		require.Equal(t, streamDNSLines[i], *event.RawLine)
+	}
+
+	// The array mode works with compressed streams
+	results, err = ParseStream(bytes.NewReader(gzipLines(t, input)), newStreamDNSParser(t), &StreamConfig{
+		Gzip:  true,
+		Input: InputJSONArray,
+	})
+	require.NoError(t, err)
+	require.Len(t, results, len(streamDNSLines))
+
+	results, err = ParseStream(strings.NewReader(" [ ] "), newStreamDNSParser(t), config)
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	results, err = ParseStream(strings.NewReader(""), newStreamDNSParser(t), config)
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
+
+func TestParseStreamJSONArrayInvalid(t *testing.T) {
+	config := &StreamConfig{Input: InputJSONArray}
+	for _, input := range []string{
+		// NDJSON is only read in the default mode
+		strings.Join(streamDNSLines, "\n"),
+		"[" + streamDNSLines[0] + ",",
+		"[" + streamDNSLines[0] + "]" + streamDNSLines[1],
+		`{"elements":[]}`,
+		"[" + streamDNSLines[0] + ",]",
+		"[," + streamDNSLines[0] + "]",
+		"[" + streamDNSLines[0] + " " + streamDNSLines[1] + "]",
+		"[" + streamDNSLines[0] + "}]",
+	} {
+		_, err := ParseStream(strings.NewReader(input), newStreamDNSParser(t), config)
+		require.Error(t, err, input)
+	}
+
+	// Elements that fail to parse are reported as line errors
+	iter := NewLineIterator(strings.NewReader(`[{"ts":"invalid"},`+streamDNSLines[0]+`]`), newStreamDNSParser(t), config)
+	require.True(t, iter.Next())
+	require.Error(t, iter.LineErr())
+	require.True(t, iter.Next())
+	require.NoError(t, iter.LineErr())
+	require.Len(t, iter.Results(), 1)
+	require.False(t, iter.Next())
+	require.NoError(t, iter.Err())
+}
+
+func TestParseStreamJSONArrayMaxLength(t *testing.T) {
+	// Brackets and escaped quotes in strings do not end elements
+	oversize := `{"ts":1541001600.580233,"uid":"` + strings.Repeat(`[\"}`, 16*1024) + `"}`
+	input := "[" + strings.Join([]string{streamDNSLines[0], oversize, streamDNSLines[1], "[" + oversize + "]", streamDNSLines[2]}, ",") + "]"
+	config := &StreamConfig{
+		Input:         InputJSONArray,
+		MaxLineLength: len(streamDNSLines[0]),
+	}
+	iter := NewLineIterator(strings.NewReader(input), newStreamDNSParser(t), config)
+	var queries []string
+	var lineErrors []error
+	for iter.Next() {
+		if err := iter.LineErr(); err != nil {
+			lineErrors = append(lineErrors, err)
+			continue
+		}
+		require.Len(t, iter.Results(), 1)
+		queries = append(queries, *iter.Results()[0].Event.(*ZeekDNS).Query)
+	}
+	require.NoError(t, iter.Err())
+	require.Equal(t, []string{"www.example.com", "www.example.org", "www.example.net"}, queries)
+	require.Len(t, lineErrors, 2)
+	tooLong := &LineTooLongError{}
+	require.True(t, errors.As(lineErrors[0], &tooLong))
+	require.Equal(t, &LineTooLongError{
+		Length:    len(oversize),
+		MaxLength: len(streamDNSLines[0]),
+	}, tooLong)
+	require.True(t, errors.As(lineErrors[1], &tooLong))
+	require.Equal(t, len(oversize)+2, tooLong.Length)
+}
+
+func TestLineIterator(t *testing.T) {
+	const numLines = 100
+	lines := make([]string, numLines)
+	for i := range lines {
+		if i%10 == 9 {
+			lines[i] = `{"ts":1541001600.580233,"uid":`
+			continue
+		}
+		// nolint:lll
+		lines[i] = fmt.Sprintf(`{"ts":1541001600.580233,"uid":"C%d","id.orig_h":"172.16.2.16","id.orig_p":43720,"id.resp_h":"172.16.0.2","id.resp_p":53,"proto":"udp","query":"host%d.example.com"}`, i, i)
+	}
+
+	for _, gzipped := range []bool{false, true} {
+		input := []byte(strings.Join(lines, "\n"))
+		if gzipped {
+			input = gzipLines(t, lines...)
+		}
+		iter := NewLineIterator(bytes.NewReader(input), newStreamDNSParser(t), &StreamConfig{Gzip: true})
+		var numEvents int
+		var lineErrors []uint64
+		for iter.Next() {
+			if err := iter.LineErr(); err != nil {
+				require.Nil(t, iter.Results())
+				lineErr := &LineError{}
+				require.True(t, errors.As(err, &lineErr))
+				lineErrors = append(lineErrors, lineErr.Line)
+				continue
+			}
+			results := iter.Results()
+			require.Len(t, results, 1)
+			event := results[0].Event.(*ZeekDNS)
+			require.Equal(t, fmt.Sprintf("C%d", numEvents+len(lineErrors)), *event.UID)
+			numEvents++
+		}
+		require.NoError(t, iter.Err())
+		require.False(t, iter.Next())
+		require.Equal(t, 90, numEvents)
+		require.Equal(t, []uint64{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}, lineErrors)
+	}
+}
+
+func TestLineIteratorStreamError(t *testing.T) {
+	input := gzipLines(t, streamDNSLines...)
+	iter := NewLineIterator(bytes.NewReader(input[:len(input)-4]), newStreamDNSParser(t), &StreamConfig{Gzip: true})
+	for iter.Next() {
+		require.NoError(t, iter.LineErr())
+	}
+	require.Error(t, iter.Err())
+	require.False(t, iter.Next())
+
+	iter = NewLineIterator(strings.NewReader(gzipMagic), newStreamDNSParser(t), &StreamConfig{Gzip: true})
+	require.False(t, iter.Next())
+	require.Error(t, iter.Err())
+}
+
+func TestLineIteratorMaxLineLength(t *testing.T) {
+	// The oversize line is much longer than the read buffer of the stream
+	oversize := `{"ts":1541001600.580233,"uid":"` + strings.Repeat("C", 64*1024) + `"}`
+	lines := []string{streamDNSLines[0], oversize, streamDNSLines[1], "", streamDNSLines[2]}
+	input := strings.Join(lines, "\r\n")
+
+	iter := NewLineIterator(strings.NewReader(input), newStreamDNSParser(t), &StreamConfig{
+		MaxLineLength: len(streamDNSLines[0]),
+	})
+	var queries []string
+	var lineErrors []error
+	for iter.Next() {
+		if err := iter.LineErr(); err != nil {
+			lineErrors = append(lineErrors, err)
+			continue
+		}
+		require.Len(t, iter.Results(), 1)
+		queries = append(queries, *iter.Results()[0].Event.(*ZeekDNS).Query)
+	}
+	require.NoError(t, iter.Err())
+	require.Equal(t, []string{"www.example.com", "www.example.org", "www.example.net"}, queries)
+	require.Len(t, lineErrors, 1)
+	tooLong := &LineTooLongError{}
+	require.True(t, errors.As(lineErrors[0], &tooLong))
+	require.Equal(t, &LineTooLongError{
+		Length:    len(oversize),
+		MaxLength: len(streamDNSLines[0]),
+	}, tooLong)
+
+	// The default maximum allows the line so it is parsed and fails validation
+	results, err := ParseStream(strings.NewReader(input), newStreamDNSParser(t), nil)
+	require.Error(t, err)
+	require.False(t, errors.As(err, &tooLong))
+	require.Len(t, results, len(streamDNSLines))
+}
+
+func TestLineIteratorMaxLineLengthLineErrors(t *testing.T) {
+	oversize := `{"ts":1541001600.580233,"uid":"` + strings.Repeat("C", 64*1024) + `"}`
+	lines := []string{streamDNSLines[0], oversize, `{"ts":"foo"}`, streamDNSLines[1]}
+	observer := &countingObserver{
+		parsed: map[string]int{},
+		failed: map[string]int{},
+	}
+	SetObserver(observer)
+	defer SetObserver(nil)
+
+	for _, config := range []StreamConfig{
+		{MaxLineLength: len(streamDNSLines[0])},
+		{MaxLineLength: len(streamDNSLines[0]), Input: InputJSONArray},
+	} {
+		input := strings.Join(lines, "\n")
+		if config.Input == InputJSONArray {
+			input = "[" + strings.Join(lines, ",") + "]"
+		}
+		var reported []uint64
+		onError := func(err *LineError) {
+			reported = append(reported, err.Line)
+		}
+		adapter, err := adapterFactory(&ZeekDNSParser{}).NewParser(&AdapterConfig{OnError: onError})
+		require.NoError(t, err)
+		multiParser := NewMultiParser(&AdapterConfig{OnError: onError})
+		for _, parser := range []parsers.Interface{adapter, multiParser} {
+			reported = nil
+			iter := NewLineIterator(strings.NewReader(input), parser, &config)
+			var lineErrors []*LineError
+			for iter.Next() {
+				if err := iter.LineErr(); err != nil {
+					lineErr := &LineError{}
+					require.True(t, errors.As(err, &lineErr))
+					lineErrors = append(lineErrors, lineErr)
+				}
+			}
+			require.NoError(t, iter.Err())
+			require.Len(t, lineErrors, 2)
+			// The skipped line is counted so that the following errors have the line numbers of the stream
+			require.Equal(t, uint64(2), lineErrors[0].Line)
+			tooLong := &LineTooLongError{}
+			require.True(t, errors.As(lineErrors[0], &tooLong))
+			require.Equal(t, uint64(3), lineErrors[1].Line)
+			require.Equal(t, []uint64{2, 3}, reported)
+		}
+		require.Equal(t, uint64(len(lines)), adapter.(*Adapter).NumLines())
+		require.Equal(t, uint64(2), adapter.(*Adapter).NumErrors())
+		require.Equal(t, uint64(len(lines)), multiParser.numLines)
+	}
+	// Only the lines of the Adapter are reported since the failed lines of the MultiParser do not match any Zeek log
+	require.Equal(t, map[string]int{TypeZeekDNS: 4}, observer.failed)
+}
+
+func TestParseStreamLineErrors(t *testing.T) {
+	oversize := `{"ts":1541001600.580233,"uid":"` + strings.Repeat("C", 64*1024) + `"}`
+	lines := []string{streamDNSLines[0], oversize, streamDNSLines[1], `{"ts":"foo"}`, streamDNSLines[2]}
+	input := strings.Join(lines, "\n")
+
+	results, err := ParseStream(strings.NewReader(input), newStreamDNSParser(t), &StreamConfig{
+		MaxLineLength: len(streamDNSLines[0]),
+	})
+	require.Len(t, results, len(streamDNSLines))
+	for i, result := range results {
+		require.Equal(t, []string{"www.example.com", "www.example.org", "www.example.net"}[i], *result.Event.(*ZeekDNS).Query)
+	}
+	lineErrors := LineErrors{}
+	require.True(t, errors.As(err, &lineErrors))
+	require.Len(t, lineErrors, 2)
+	tooLong := &LineTooLongError{}
+	require.True(t, errors.As(lineErrors[0], &tooLong))
+	lineErr := &LineError{}
+	require.True(t, errors.As(lineErrors[1], &lineErr))
+	require.Equal(t, TypeZeekDNS, lineErr.LogType)
+	require.Contains(t, err.Error(), "2 zeek log lines failed to parse")
+
+	// Read errors still stop parsing
+	input = string(gzipLines(t, streamDNSLines...))
+	results, err = ParseStream(strings.NewReader(input[:len(input)-4]), newStreamDNSParser(t), &StreamConfig{Gzip: true})
+	require.Error(t, err)
+	require.False(t, errors.As(err, &lineErrors))
+	require.Empty(t, results)
+}
+
+func TestParseString(t *testing.T) {
+	parser := &ZeekDNSParser{}
+	results, err := parser.ParseString(streamDNSLines[0])
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, TypeZeekDNS, results[0].PantherLogType)
+	require.Equal(t, "www.example.com", *results[0].Event.(*ZeekDNS).Query)
+
+	// TSV header directives are kept by the parser for the next lines
+	results, err = parser.ParseString("#fields\tts\tuid\tid.orig_h\tid.orig_p\tid.resp_h\tid.resp_p\tproto\tquery")
+	require.NoError(t, err)
+	require.Empty(t, results)
+	results, err = parser.ParseString("1541001600.580233\tCpR9AY39cUCZ0t5qq6\t172.16.2.16\t43720\t172.16.0.2\t53\tudp\twww.example.org")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "www.example.org", *results[0].Event.(*ZeekDNS).Query)
+
+	_, err = parser.ParseString(`{"ts":1541001600.580233,"uid":`)
+	require.Error(t, err)
+}
@@ -0,0 +1,108 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekSyslog struct {
+	TS       *Time   `json:"ts,omitempty" validate:"required" description:"Timestamp when the syslog message was seen."`
+	UID      *string `json:"uid,omitempty" validate:"required" description:"A unique identifier of the connection."`
+	IDOrigH  *string `json:"id.orig_h" panther:"ip" validate:"required" description:"The originator’s IP address."`
+	IDOrigP  *uint16 `json:"id.orig_p" validate:"required" description:"The originator’s port number."`
+	IDRespH  *string `json:"id.resp_h" panther:"ip" validate:"required" description:"The responder’s IP address."`
+	IDRespP  *uint16 `json:"id.resp_p" validate:"required" description:"The responder’s port number."`
+	Proto    *string `json:"proto" validate:"required" description:"Protocol over which the message was seen."`
+	Facility *string `json:"facility,omitempty" description:"Syslog facility for the message."`
+	Severity *string `json:"severity,omitempty" description:"Syslog severity for the message."`
+	Message  *string `json:"message,omitempty" description:"The plain text message. It can be arbitrarily long and contain newlines, which Zeek escapes in the log."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekSyslogParser parses zeek syslog logs
+type ZeekSyslogParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekSyslogParser)(nil)
+
+func (p *ZeekSyslogParser) New() parsers.LogParser {
+	return &ZeekSyslogParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekSyslogParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekSyslog := &ZeekSyslog{}
+
+	ok, err := p.decoder.Decode(log, pathSyslog, zeekSyslog, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekSyslog.updatePantherFields(p)
+	p.Indicators.apply(&zeekSyslog.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekSyslog); err != nil {
+		return nil, err
+	}
+
+	return zeekSyslog.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekSyslogParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekSyslogParser) LogType() string {
+	return TypeZeekSyslog
+}
+
+func (event *ZeekSyslog) updatePantherFields(p *ZeekSyslogParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+}
@@ -0,0 +1,126 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekSyslog(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CtZMd44KxI9WF5ijvb","id.orig_h":"10.0.0.23","id.orig_p":514,"id.resp_h":"10.0.0.2","id.resp_p":514,"proto":"udp","facility":"LOCAL0","severity":"NOTICE","message":"sshd[4242]: Accepted publickey for admin from 10.0.0.42 port 52113 ssh2"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekSyslog{
+		TS:       (*Time)(&expectedTime),
+		UID:      aws.String("CtZMd44KxI9WF5ijvb"),
+		IDOrigH:  aws.String("10.0.0.23"),
+		IDOrigP:  aws.Uint16(514),
+		IDRespH:  aws.String("10.0.0.2"),
+		IDRespP:  aws.Uint16(514),
+		Proto:    aws.String("udp"),
+		Facility: aws.String("LOCAL0"),
+		Severity: aws.String("NOTICE"),
+		Message:  aws.String("sshd[4242]: Accepted publickey for admin from 10.0.0.42 port 52113 ssh2"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Syslog")
+	expectedEvent.AppendAnyIPAddress("10.0.0.23")
+	expectedEvent.AppendAnyIPAddress("10.0.0.2")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekSyslog(t, log, expectedEvent)
+}
+
+func TestZeekSyslogMultiLineMessage(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CtZMd44KxI9WF5ijvb","id.orig_h":"10.0.0.23","id.orig_p":514,"id.resp_h":"10.0.0.2","id.resp_p":514,"proto":"tcp","facility":"USER","severity":"ERR","message":"java.lang.NullPointerException\n\tat com.example.App.main(App.java:14)\r\n\tat java.base/java.lang.Thread.run(Thread.java:834)"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekSyslog{
+		TS:       (*Time)(&expectedTime),
+		UID:      aws.String("CtZMd44KxI9WF5ijvb"),
+		IDOrigH:  aws.String("10.0.0.23"),
+		IDOrigP:  aws.Uint16(514),
+		IDRespH:  aws.String("10.0.0.2"),
+		IDRespP:  aws.Uint16(514),
+		Proto:    aws.String("tcp"),
+		Facility: aws.String("USER"),
+		Severity: aws.String("ERR"),
+		Message:  aws.String("java.lang.NullPointerException\n\tat com.example.App.main(App.java:14)\r\n\tat java.base/java.lang.Thread.run(Thread.java:834)"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Syslog")
+	expectedEvent.AppendAnyIPAddress("10.0.0.23")
+	expectedEvent.AppendAnyIPAddress("10.0.0.2")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekSyslog(t, log, expectedEvent)
+
+	// TSV logs escape the newlines and tabs of the message
+	lines := []string{
+		`#separator \x09`,
+		"#path\tsyslog",
+		"#fields\tts\tuid\tid.orig_h\tid.orig_p\tid.resp_h\tid.resp_p\tproto\tfacility\tseverity\tmessage",
+		`1541001600.580233	CtZMd44KxI9WF5ijvb	10.0.0.23	514	10.0.0.2	514	tcp	USER	ERR	java.lang.NullPointerException\x0a\x09at com.example.App.main(App.java:14)\x0d\x0a\x09at java.base/java.lang.Thread.run(Thread.java:834)`,
+	}
+	parser := &ZeekSyslogParser{}
+	for _, line := range lines[:len(lines)-1] {
+		logs, err := parser.Parse(line)
+		require.NoError(t, err)
+		require.Nil(t, logs)
+	}
+	logs, err := parser.Parse(lines[len(lines)-1])
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
+
+func TestZeekSyslogLongMessage(t *testing.T) {
+	message := strings.Repeat("lorem ipsum dolor sit amet\\n", 10000)
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CtZMd44KxI9WF5ijvb","id.orig_h":"10.0.0.23","id.orig_p":514,"id.resp_h":"10.0.0.2","id.resp_p":514,"proto":"tcp","message":"` + message + `"}`
+
+	results, err := ParseStream(strings.NewReader(log+"\n"+log+"\n"), NewAdapter(TypeZeekSyslog, parsers.NewAdapter(&ZeekSyslogParser{}), nil), nil)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	expectedMessage := strings.Repeat("lorem ipsum dolor sit amet\n", 10000)
+	for _, result := range results {
+		require.Equal(t, expectedMessage, *result.Event.(*ZeekSyslog).Message)
+	}
+}
+
+func TestZeekSyslogType(t *testing.T) {
+	parser := &ZeekSyslogParser{}
+	require.Equal(t, "Zeek.Syslog", parser.LogType())
+}
+
+func checkZeekSyslog(t *testing.T, log string, expectedEvent *ZeekSyslog) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekSyslogParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
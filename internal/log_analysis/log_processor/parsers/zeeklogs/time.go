@@ -0,0 +1,211 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+)
+
+// Time is a Zeek `time` value, the number of seconds since the epoch with a fractional part.
+// Zeek logs times with microsecond precision (i.e. `1591367999.123456`) which is lost if the value is decoded as a
+// float64 so the integer and fractional parts are decoded separately.
+type Time time.Time
+
+// maxTimeSeconds is the last second of year 9999, the maximum timestamp supported by Glue
+const maxTimeSeconds = 253402300799
+
+func init() {
+	awsglue.MustRegisterMapping(reflect.TypeOf(Time{}), awsglue.GlueTimestampType)
+}
+
+func (ts *Time) String() string {
+	return (*time.Time)(ts).UTC().String() // ensure UTC
+}
+
+func (ts *Time) MarshalJSON() ([]byte, error) {
+	return []byte((*time.Time)(ts).UTC().Format(awsglue.TimestampLayoutJSON)), nil // ensure UTC
+}
+
+func (ts *Time) UnmarshalJSON(jsonBytes []byte) error {
+	tm, err := parseTime(strings.Trim(string(jsonBytes), `"`))
+	if err != nil {
+		return err
+	}
+	*ts = (Time)(tm)
+	return nil
+}
+
+// parseTime parses Zeek time values
+func parseTime(value string) (time.Time, error) {
+	if strings.ContainsAny(value, "eE") {
+		// Exponent notation is not used by Zeek for times, parse as float for completeness
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return time.Time{}, errors.Errorf("invalid zeek time %q", value)
+		}
+		value = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	sec, frac := value, ""
+	if pos := strings.IndexByte(value, '.'); pos != -1 {
+		sec, frac = value[:pos], value[pos+1:]
+	}
+	if strings.HasPrefix(sec, "-") {
+		return time.Time{}, errors.Errorf("negative zeek time %q", value)
+	}
+	seconds, err := strconv.ParseInt(sec, 10, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return time.Time{}, errors.Errorf("zeek time %q out of range", value)
+		}
+		return time.Time{}, errors.Errorf("invalid zeek time %q", value)
+	}
+	if seconds > maxTimeSeconds {
+		return time.Time{}, errors.Errorf("zeek time %q out of range", value)
+	}
+	nanoseconds, err := parseNanoseconds(frac)
+	if err != nil {
+		return time.Time{}, errors.Errorf("invalid zeek time %q", value)
+	}
+	return time.Unix(seconds, nanoseconds).UTC(), nil
+}
+
+// parseNanoseconds parses the digits of the fractional part of a second
+// Digits beyond nanosecond precision are truncated.
+func parseNanoseconds(digits string) (int64, error) {
+	const numDigits = 9
+	if len(digits) > numDigits {
+		digits = digits[:numDigits]
+	}
+	var nsec int64
+	for i := 0; i < numDigits; i++ {
+		nsec *= 10
+		if i >= len(digits) {
+			continue
+		}
+		c := digits[i]
+		if c < '0' || c > '9' {
+			return 0, errors.New("invalid digit")
+		}
+		nsec += int64(c - '0')
+	}
+	return nsec, nil
+}
+
+var typTime = reflect.TypeOf(Time{})
+
+// shiftTimes adds `offset` to all timestamps of an event
+func shiftTimes(event interface{}, offset time.Duration) {
+	if offset == 0 {
+		return
+	}
+	shiftTimesValue(reflect.ValueOf(event), offset)
+}
+
+func shiftTimesValue(v reflect.Value, offset time.Duration) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			shiftTimesValue(v.Elem(), offset)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			shiftTimesValue(v.Index(i), offset)
+		}
+	case reflect.Struct:
+		switch v.Type() {
+		case typTime:
+			if v.CanAddr() {
+				tm := v.Addr().Interface().(*Time)
+				*tm = Time(time.Time(*tm).Add(offset))
+			}
+		case typPantherLog:
+			// Panther fields are set after decoding
+		default:
+			typ := v.Type()
+			for i := 0; i < typ.NumField(); i++ {
+				if typ.Field(i).PkgPath == "" {
+					shiftTimesValue(v.Field(i), offset)
+				}
+			}
+		}
+	}
+}
+
+// TimeRangeError is the error returned for events with a `ts` outside the time range of a parser,
+// i.e. the far-future or epoch-zero timestamps of a sensor with a broken clock
+type TimeRangeError struct {
+	Time time.Time
+	// MinTime and MaxTime are the bounds of the range, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+}
+
+func (e *TimeRangeError) Error() string {
+	return fmt.Sprintf("out-of-range zeek timestamp %s (expected %s to %s)",
+		formatTimeBound(e.Time), formatTimeBound(e.MinTime), formatTimeBound(e.MaxTime))
+}
+
+func formatTimeBound(tm time.Time) string {
+	if tm.IsZero() {
+		return "unbounded"
+	}
+	return tm.UTC().Format(time.RFC3339Nano)
+}
+
+// timeRange holds the optional bounds for the timestamps of events
+type timeRange struct {
+	min time.Time
+	max time.Time
+}
+
+func (r timeRange) isZero() bool {
+	return r.min.IsZero() && r.max.IsZero()
+}
+
+// check fails with a *TimeRangeError if `tm` is before the min or after the max of the range
+func (r timeRange) check(tm time.Time) error {
+	if (!r.min.IsZero() && tm.Before(r.min)) || (!r.max.IsZero() && tm.After(r.max)) {
+		return &TimeRangeError{
+			Time:    tm,
+			MinTime: r.min,
+			MaxTime: r.max,
+		}
+	}
+	return nil
+}
+
+// secondsToMillis converts a Zeek interval in seconds to milliseconds.
+// Zeek logs intervals with microsecond precision so the result is rounded to microseconds to avoid floating point
+// artifacts (i.e. 0.0123 seconds is 12.3 milliseconds and not 12.299999999999999).
+func secondsToMillis(seconds *float64) *float64 {
+	if seconds == nil {
+		return nil
+	}
+	millis := math.Round(*seconds*1e6) / 1e3
+	return &millis
+}
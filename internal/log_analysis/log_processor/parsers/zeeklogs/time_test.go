@@ -0,0 +1,103 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeUnmarshal(t *testing.T) {
+	var ts Time
+	require.NoError(t, jsoniter.UnmarshalFromString(`1591367999.123456`, &ts))
+	tm := time.Time(ts)
+	require.Equal(t, time.Date(2020, 6, 5, 14, 39, 59, 123456000, time.UTC), tm)
+	require.Equal(t, 123456, tm.Nanosecond()/int(time.Microsecond))
+
+	// Round trip
+	data, err := jsoniter.Marshal(&ts)
+	require.NoError(t, err)
+	require.Equal(t, `"2020-06-05 14:39:59.123456000"`, string(data))
+}
+
+func TestParseTime(t *testing.T) {
+	for input, expect := range map[string]time.Time{
+		`1591367999`:             time.Date(2020, 6, 5, 14, 39, 59, 0, time.UTC),
+		`1591367999.1`:           time.Date(2020, 6, 5, 14, 39, 59, 100000000, time.UTC),
+		`1591367999.000001`:      time.Date(2020, 6, 5, 14, 39, 59, 1000, time.UTC),
+		`1591367999.12345678912`: time.Date(2020, 6, 5, 14, 39, 59, 123456789, time.UTC),
+		`1.591367999e9`:          time.Date(2020, 6, 5, 14, 39, 59, 0, time.UTC),
+		`0.0`:                    time.Unix(0, 0).UTC(),
+	} {
+		tm, err := parseTime(input)
+		require.NoError(t, err, input)
+		require.Equal(t, expect, tm, input)
+	}
+}
+
+func TestParseTimeInvalid(t *testing.T) {
+	for _, input := range []string{
+		``,
+		`-1591367999.123456`,
+		`253402300800`,
+		`99999999999999999999`,
+		`1591367999.12a`,
+		`abc`,
+	} {
+		_, err := parseTime(input)
+		require.Error(t, err, input)
+	}
+	var ts Time
+	require.Error(t, jsoniter.UnmarshalFromString(`-1.0`, &ts))
+}
+
+func TestTimeOffset(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"_write_ts":1541001601.0,"id":"FjsOce3qZH1h3C5mJ4","certificate.not_valid_before":1543536000.0}`
+	parser := &ZeekX509Parser{TimeOffset: 2 * time.Hour}
+	results, err := parser.New().Parse(log)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	expectedTime := time.Date(2018, 10, 31, 18, 0, 0, 580233000, time.UTC)
+	require.Equal(t, expectedTime, time.Time(*results[0].PantherEventTime))
+
+	event := results[0].Event().(*ZeekX509)
+	require.Equal(t, expectedTime, time.Time(*event.TS))
+	require.Equal(t, time.Date(2018, 10, 31, 18, 0, 1, 0, time.UTC), time.Time(*event.WriteTS))
+	require.Equal(t, time.Date(2018, 11, 30, 2, 0, 0, 0, time.UTC), time.Time(*event.CertificateNotValidBefore))
+
+	// The default is no offset
+	results, err = (&ZeekX509Parser{}).Parse(log)
+	require.NoError(t, err)
+	require.Equal(t, expectedTime.Add(-2*time.Hour), time.Time(*results[0].PantherEventTime))
+}
+
+func TestSecondsToMillis(t *testing.T) {
+	seconds := func(v float64) *float64 {
+		return &v
+	}
+	require.Nil(t, secondsToMillis(nil))
+	require.Equal(t, 12.3, *secondsToMillis(seconds(0.0123)))
+	require.Equal(t, 0.001, *secondsToMillis(seconds(0.000001)))
+	require.Equal(t, 1500.0, *secondsToMillis(seconds(1.5)))
+	require.Equal(t, 0.0, *secondsToMillis(seconds(0)))
+}
@@ -0,0 +1,308 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+// Default values for the header directives of Zeek TSV logs
+// https://docs.zeek.org/en/current/scripts/base/frameworks/logging/writers/ascii.zeek.html
+const (
+	tsvDefaultSeparator    = "\t"
+	tsvDefaultSetSeparator = ","
+	tsvDefaultEmptyField   = "(empty)"
+	tsvDefaultUnsetField   = "-"
+)
+
+// tsvHeader holds the directives found in the header of a Zeek TSV log
+type tsvHeader struct {
+	Separator    string
+	SetSeparator string
+	EmptyField   string
+	UnsetField   string
+	Path         string
+	Fields       []string
+	Types        []string
+}
+
+func (h *tsvHeader) reset() {
+	*h = tsvHeader{
+		Separator:    tsvDefaultSeparator,
+		SetSeparator: tsvDefaultSetSeparator,
+		EmptyField:   tsvDefaultEmptyField,
+		UnsetField:   tsvDefaultUnsetField,
+	}
+}
+
+// checkArity fails if the header has a different number of `#fields` and `#types`.
+// A truncated header would map the values of every row to the wrong fields.
+func (h *tsvHeader) checkArity() error {
+	if h.Fields != nil && h.Types != nil && len(h.Fields) != len(h.Types) {
+		return errors.Errorf("zeek TSV header has %d #fields but %d #types", len(h.Fields), len(h.Types))
+	}
+	return nil
+}
+
+// tsvColumn maps a column of a Zeek TSV log to a JSON field of an event struct
+type tsvColumn struct {
+	Name string
+	Kind reflect.Kind
+	// Elem is the kind of the elements for set and vector columns
+	Elem reflect.Kind
+}
+
+// tsvReader converts the data rows of a Zeek TSV log to JSON objects using the header directives.
+// The column mapping is derived once from the `#fields` header and cached until a new header is read.
+// The zero value is ready to use and assumes the default Zeek directives until a header is read.
+type tsvReader struct {
+	header  *tsvHeader
+	columns []tsvColumn
+	stream  *jsoniter.Stream
+}
+
+// HasFields checks if the reader has read a `#fields` header
+func (r *tsvReader) HasFields() bool {
+	return r.header != nil && r.header.Fields != nil
+}
+
+// ReadDirective reads a header directive line
+// It fails if the `#path` directive does not match `path`.
+func (r *tsvReader) ReadDirective(line, path string) error {
+	if r.header == nil {
+		r.header = &tsvHeader{}
+		r.header.reset()
+	}
+	// The separator directive is always separated by a space from its value
+	const separatorDirective = "#separator "
+	if strings.HasPrefix(line, separatorDirective) {
+		r.header.reset()
+		r.columns = nil
+		sep := unescapeTSV(strings.TrimPrefix(line, separatorDirective))
+		if sep == "" {
+			return errors.New("empty zeek TSV separator")
+		}
+		r.header.Separator = sep
+		return nil
+	}
+	parts := strings.Split(line, r.header.Separator)
+	directive, values := parts[0], parts[1:]
+	value := strings.Join(values, r.header.Separator)
+	switch directive {
+	case "#set_separator":
+		r.header.SetSeparator = unescapeTSV(value)
+	case "#empty_field":
+		r.header.EmptyField = value
+	case "#unset_field":
+		r.header.UnsetField = value
+	case "#path":
+		r.header.Path = value
+	case "#fields":
+		r.header.Fields = values
+		r.columns = nil
+		if err := r.header.checkArity(); err != nil {
+			return err
+		}
+	case "#types":
+		r.header.Types = values
+		if err := r.header.checkArity(); err != nil {
+			return err
+		}
+	case "#open", "#close":
+	default:
+		return errors.Errorf("invalid zeek TSV directive %q", directive)
+	}
+	if r.header.Path != "" && r.header.Path != path {
+		return errors.Errorf("zeek TSV log path %q does not match %q", r.header.Path, path)
+	}
+	return nil
+}
+
+// ReadRow converts a data row to a JSON object for the fields of `event`.
+// It fails if the row does not have a value for each of the `#fields` of the header.
+// The returned bytes are only valid until the next call to ReadRow.
+func (r *tsvReader) ReadRow(line string, event interface{}) ([]byte, error) {
+	if !r.HasFields() {
+		return nil, errors.New("zeek TSV row without a #fields header")
+	}
+	if r.columns == nil {
+		r.columns = mapTSVColumns(r.header.Fields, reflect.TypeOf(event))
+	}
+	if r.stream == nil {
+		r.stream = jsoniter.NewStream(jsoniter.ConfigDefault, nil, 4096)
+	}
+	stream := r.stream
+	stream.Reset(nil)
+	stream.Error = nil
+	values := strings.Split(line, r.header.Separator)
+	if len(values) != len(r.columns) {
+		return nil, errors.Errorf("zeek TSV row has %d columns but the #fields header has %d", len(values), len(r.columns))
+	}
+	stream.WriteObjectStart()
+	numFields := 0
+	for i, value := range values {
+		if value == r.header.UnsetField {
+			continue
+		}
+		if numFields > 0 {
+			stream.WriteMore()
+		}
+		numFields++
+		col := &r.columns[i]
+		stream.WriteObjectField(col.Name)
+		if col.Kind == reflect.Slice {
+			r.writeSet(stream, col.Elem, value)
+			continue
+		}
+		if value == r.header.EmptyField {
+			value = ""
+		}
+		writeTSVValue(stream, col.Kind, unescapeTSV(value))
+	}
+	stream.WriteObjectEnd()
+	if err := stream.Error; err != nil {
+		return nil, err
+	}
+	return stream.Buffer(), nil
+}
+
+func (r *tsvReader) writeSet(stream *jsoniter.Stream, kind reflect.Kind, value string) {
+	stream.WriteArrayStart()
+	h := r.header
+	for i, el := range splitSet(value, h.SetSeparator, h.EmptyField, h.UnsetField) {
+		if i > 0 {
+			stream.WriteMore()
+		}
+		writeTSVValue(stream, kind, el)
+	}
+	stream.WriteArrayEnd()
+}
+
+func writeTSVValue(stream *jsoniter.Stream, kind reflect.Kind, value string) {
+	switch kind {
+	case reflect.Bool:
+		switch value {
+		case "T":
+			stream.WriteTrue()
+			return
+		case "F":
+			stream.WriteFalse()
+			return
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Struct:
+		// Numbers and timestamps are written as-is so the event decoders handle them the same way as in JSON logs
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			stream.WriteRaw(value)
+			return
+		}
+	}
+	// Any invalid value is written as a string so that decoding the event fails with a type error
+	stream.WriteString(value)
+}
+
+// mapTSVColumns maps the names of a `#fields` header to the JSON fields of an event struct.
+// Columns that do not match any field are mapped as strings.
+func mapTSVColumns(fields []string, typ reflect.Type) []tsvColumn {
+	kinds := map[string]reflect.Type{}
+	collectFieldTypesJSON(kinds, typ)
+	columns := make([]tsvColumn, len(fields))
+	for i, name := range fields {
+		col := tsvColumn{
+			Name: name,
+			Kind: reflect.String,
+		}
+		if fieldType, ok := kinds[name]; ok {
+			col.Kind = fieldType.Kind()
+			if col.Kind == reflect.Slice {
+				col.Elem = derefType(fieldType.Elem()).Kind()
+			}
+		}
+		columns[i] = col
+	}
+	return columns
+}
+
+// collectFieldTypesJSON collects the (dereferenced) types of all JSON fields of a struct by name.
+// The fields of embedded structs are collected except for the panther fields added to each event.
+// Fields are also collected by the names in their `aliases` tag.
+func collectFieldTypesJSON(dst map[string]reflect.Type, typ reflect.Type) {
+	typ = derefType(typ)
+	if typ.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Anonymous {
+			if field.Type != typPantherLog {
+				collectFieldTypesJSON(dst, field.Type)
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		dst[name] = derefType(field.Type)
+		for _, alias := range fieldAliases(field.Tag) {
+			dst[alias] = derefType(field.Type)
+		}
+	}
+}
+
+var typPantherLog = reflect.TypeOf(ZeekPantherLog{})
+
+func derefType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+// unescapeTSV replaces the `\xHH` escape sequences Zeek uses for separators and non-printable characters
+func unescapeTSV(s string) string {
+	if !strings.Contains(s, `\x`) {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) && s[i+1] == 'x' {
+			if c, err := strconv.ParseUint(s[i+2:i+4], 16, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
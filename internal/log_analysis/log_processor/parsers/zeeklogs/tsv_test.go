@@ -0,0 +1,117 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnescapeTSV(t *testing.T) {
+	require.Equal(t, "\t", unescapeTSV(`\x09`))
+	require.Equal(t, "a,b", unescapeTSV(`a\x2cb`))
+	require.Equal(t, `foo\x`, unescapeTSV(`foo\x`))
+	require.Equal(t, `foo\xZZ`, unescapeTSV(`foo\xZZ`))
+	require.Equal(t, "plain", unescapeTSV("plain"))
+}
+
+func TestTSVReaderReadRow(t *testing.T) {
+	type event struct {
+		Name   *string  `json:"name"`
+		Count  *uint64  `json:"count"`
+		Flag   *bool    `json:"flag"`
+		Values []string `json:"values"`
+		Nums   []uint16 `json:"nums"`
+	}
+	r := tsvReader{}
+	require.NoError(t, r.ReadDirective(`#separator \x09`, "test"))
+	require.NoError(t, r.ReadDirective("#set_separator\t,", "test"))
+	require.NoError(t, r.ReadDirective("#path\ttest", "test"))
+	require.NoError(t, r.ReadDirective("#fields\tname\tcount\tflag\tvalues\tnums\textra", "test"))
+
+	data, err := r.ReadRow("foo\\x09bar\t42\tT\ta\\x2cb,c\t1,2\textra", &event{})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"foo\tbar","count":42,"flag":true,"values":["a,b","c"],"nums":[1,2],"extra":"extra"}`, string(data))
+
+	data, err = r.ReadRow("(empty)\t-\tF\t(empty)\t-\t-", &event{})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"","flag":false,"values":[]}`, string(data))
+
+	data, err = r.ReadRow("foo\tbar\tmaybe\t-\t-\t-", &event{})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"foo","count":"bar","flag":"maybe"}`, string(data))
+}
+
+func TestTSVReaderDirectives(t *testing.T) {
+	r := tsvReader{}
+	require.False(t, r.HasFields())
+	_, err := r.ReadRow("foo", nil)
+	require.Error(t, err)
+	require.Error(t, r.ReadDirective("#foo\tbar", "dns"))
+	require.Error(t, r.ReadDirective("#path\tconn", "dns"))
+	require.NoError(t, r.ReadDirective(`#separator \x09`, "dns"))
+	require.NoError(t, r.ReadDirective("#path\tdns", "dns"))
+	require.NoError(t, r.ReadDirective("#fields\tts", "dns"))
+	require.True(t, r.HasFields())
+	// A new header resets all directives
+	require.NoError(t, r.ReadDirective(`#separator \x20`, "dns"))
+	require.False(t, r.HasFields())
+	require.NoError(t, r.ReadDirective("#fields ts uid", "dns"))
+	require.Equal(t, []string{"ts", "uid"}, r.header.Fields)
+}
+
+func TestTSVReaderHeaderArity(t *testing.T) {
+	r := tsvReader{}
+	require.NoError(t, r.ReadDirective(`#separator \x09`, "dns"))
+	require.NoError(t, r.ReadDirective("#fields\tts\tuid\tquery", "dns"))
+	err := r.ReadDirective("#types\ttime\tstring", "dns")
+	require.Error(t, err)
+	require.Equal(t, "zeek TSV header has 3 #fields but 2 #types", err.Error())
+
+	// The order of the directives does not matter
+	require.NoError(t, r.ReadDirective(`#separator \x09`, "dns"))
+	require.NoError(t, r.ReadDirective("#types\ttime\tstring", "dns"))
+	require.Error(t, r.ReadDirective("#fields\tts\tuid\tquery", "dns"))
+
+	require.NoError(t, r.ReadDirective(`#separator \x09`, "dns"))
+	require.NoError(t, r.ReadDirective("#fields\tts\tuid", "dns"))
+	require.NoError(t, r.ReadDirective("#types\ttime\tstring", "dns"))
+}
+
+func TestTSVReaderRowArity(t *testing.T) {
+	type event struct {
+		Name  *string `json:"name"`
+		Count *uint64 `json:"count"`
+	}
+	r := tsvReader{}
+	require.NoError(t, r.ReadDirective(`#separator \x09`, "test"))
+	require.NoError(t, r.ReadDirective("#fields\tname\tcount", "test"))
+
+	_, err := r.ReadRow("foo\t42\textra", &event{})
+	require.Error(t, err)
+	require.Equal(t, "zeek TSV row has 3 columns but the #fields header has 2", err.Error())
+	_, err = r.ReadRow("foo", &event{})
+	require.Error(t, err)
+	require.Equal(t, "zeek TSV row has 1 columns but the #fields header has 2", err.Error())
+
+	data, err := r.ReadRow("foo\t42", &event{})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"foo","count":42}`, string(data))
+}
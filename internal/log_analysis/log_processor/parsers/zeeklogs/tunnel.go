@@ -0,0 +1,106 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekTunnel struct {
+	TS         *Time   `json:"ts,omitempty" validate:"required" description:"Time at which some tunnel activity occurred."`
+	UID        *string `json:"uid,omitempty" description:"The unique identifier for the tunnel, which may correspond to a connection’s uid field for non-IP-in-IP tunnels."`
+	IDOrigH    *string `json:"id.orig_h,omitempty" panther:"ip" description:"The originator’s IP address."`
+	IDOrigP    *uint16 `json:"id.orig_p,omitempty" description:"The originator’s port number."`
+	IDRespH    *string `json:"id.resp_h,omitempty" panther:"ip" description:"The responder’s IP address."`
+	IDRespP    *uint16 `json:"id.resp_p,omitempty" description:"The responder’s port number."`
+	TunnelType *string `json:"tunnel_type,omitempty" description:"The type of tunnel (e.g. Tunnel::VXLAN)."`
+	Action     *string `json:"action,omitempty" description:"The type of activity that occurred (e.g. Tunnel::DISCOVER)."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekTunnelParser parses zeek tunnel logs
+type ZeekTunnelParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekTunnelParser)(nil)
+
+func (p *ZeekTunnelParser) New() parsers.LogParser {
+	return &ZeekTunnelParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekTunnelParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekTunnel := &ZeekTunnel{}
+
+	ok, err := p.decoder.Decode(log, pathTunnel, zeekTunnel, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekTunnel.updatePantherFields(p)
+	p.Indicators.apply(&zeekTunnel.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekTunnel); err != nil {
+		return nil, err
+	}
+
+	return zeekTunnel.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekTunnelParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekTunnelParser) LogType() string {
+	return TypeZeekTunnel
+}
+
+func (event *ZeekTunnel) updatePantherFields(p *ZeekTunnelParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+}
@@ -0,0 +1,80 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekTunnel(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"uid":"CHfJdT3KscIdMClCl7","id.orig_h":"10.10.0.5","id.orig_p":47374,"id.resp_h":"10.10.0.6","id.resp_p":4789,"tunnel_type":"Tunnel::VXLAN","action":"Tunnel::DISCOVER"}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekTunnel{
+		TS:         (*Time)(&expectedTime),
+		UID:        aws.String("CHfJdT3KscIdMClCl7"),
+		IDOrigH:    aws.String("10.10.0.5"),
+		IDOrigP:    aws.Uint16(47374),
+		IDRespH:    aws.String("10.10.0.6"),
+		IDRespP:    aws.Uint16(4789),
+		TunnelType: aws.String("Tunnel::VXLAN"),
+		Action:     aws.String("Tunnel::DISCOVER"),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Tunnel")
+	expectedEvent.AppendAnyIPAddress("10.10.0.5")
+	expectedEvent.AppendAnyIPAddress("10.10.0.6")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekTunnel(t, log, expectedEvent)
+}
+
+func TestZeekTunnelOnlyTimestamp(t *testing.T) {
+	log := `{"ts":1541001600.580233}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	expectedEvent := &ZeekTunnel{
+		TS: (*Time)(&expectedTime),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.Tunnel")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekTunnel(t, log, expectedEvent)
+}
+
+func TestZeekTunnelType(t *testing.T) {
+	parser := &ZeekTunnelParser{}
+	require.Equal(t, "Zeek.Tunnel", parser.LogType())
+}
+
+func checkZeekTunnel(t *testing.T, log string, expectedEvent *ZeekTunnel) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekTunnelParser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
@@ -0,0 +1,109 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekWeird struct {
+	TS      *Time   `json:"ts,omitempty" validate:"required" description:"The time when the weird occurred."`
+	UID     *string `json:"uid,omitempty" description:"If a connection is associated with this weird, this will be the connection’s unique ID."`
+	IDOrigH *string `json:"id.orig_h,omitempty" panther:"ip" description:"The originator’s IP address."`
+	IDOrigP *uint16 `json:"id.orig_p,omitempty" description:"The originator’s port number."`
+	IDRespH *string `json:"id.resp_h,omitempty" panther:"ip" description:"The responder’s IP address."`
+	IDRespP *uint16 `json:"id.resp_p,omitempty" description:"The responder’s port number."`
+	Name    *string `json:"name,omitempty" validate:"required" description:"The name of the weird that occurred (e.g. dns_unmatched_reply)."`
+	Addl    *string `json:"addl,omitempty" description:"Additional information accompanying the weird if any."`
+	Notice  *bool   `json:"notice,omitempty" description:"Indicate if this weird was also turned into a notice."`
+	Peer    *string `json:"peer,omitempty" description:"The peer that originated this weird. This is helpful in cluster deployments if a particular cluster node is having trouble to help identify which node is having trouble."`
+	Source  *string `json:"source,omitempty" description:"The source of the weird. When reported by a protocol analyzer, this is the analyzer name (e.g. DNS)."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekWeirdParser parses zeek weird logs
+type ZeekWeirdParser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekWeirdParser)(nil)
+
+func (p *ZeekWeirdParser) New() parsers.LogParser {
+	return &ZeekWeirdParser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekWeirdParser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekWeird := &ZeekWeird{}
+
+	ok, err := p.decoder.Decode(log, pathWeird, zeekWeird, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekWeird.updatePantherFields(p)
+	p.Indicators.apply(&zeekWeird.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekWeird); err != nil {
+		return nil, err
+	}
+
+	return zeekWeird.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekWeirdParser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekWeirdParser) LogType() string {
+	return TypeZeekWeird
+}
+
+func (event *ZeekWeird) updatePantherFields(p *ZeekWeirdParser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.orig_h", event.IDOrigH)
+	appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "id.resp_h", event.IDRespH)
+}
@@ -0,0 +1,124 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"time"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+// nolint:lll
+type ZeekX509 struct {
+	TS                        *Time   `json:"ts,omitempty" validate:"required" description:"Current timestamp."`
+	ID                        *string `json:"id,omitempty" validate:"required" description:"File id of this certificate."`
+	CertificateVersion        *uint64 `json:"certificate.version,omitempty" description:"Version number."`
+	CertificateSerial         *string `json:"certificate.serial,omitempty" description:"Serial number."`
+	CertificateSubject        *string `json:"certificate.subject,omitempty" description:"Subject."`
+	CertificateIssuer         *string `json:"certificate.issuer,omitempty" description:"Issuer."`
+	CertificateNotValidBefore *Time   `json:"certificate.not_valid_before,omitempty" description:"Timestamp before when certificate is not valid."`
+	CertificateNotValidAfter  *Time   `json:"certificate.not_valid_after,omitempty" description:"Timestamp after when certificate is not valid."`
+	CertificateKeyAlg         *string `json:"certificate.key_alg,omitempty" description:"Name of the key algorithm."`
+	CertificateSigAlg         *string `json:"certificate.sig_alg,omitempty" description:"Name of the signature algorithm."`
+	CertificateKeyType        *string `json:"certificate.key_type,omitempty" description:"Key type, if key parseable by openssl (either rsa, dsa or ec)."`
+	CertificateKeyLength      *uint64 `json:"certificate.key_length,omitempty" description:"Key length in bits."`
+	CertificateExponent       *string `json:"certificate.exponent,omitempty" description:"Exponent, if RSA-certificate."`
+	CertificateCurve          *string `json:"certificate.curve,omitempty" description:"Curve, if EC-certificate."`
+	SANDNS                    Set     `json:"san.dns,omitempty" panther:"domain" description:"List of DNS entries in the Subject Alternative Name extension."`
+	SANURI                    Set     `json:"san.uri,omitempty" description:"List of URI entries in the Subject Alternative Name extension."`
+	SANEmail                  Set     `json:"san.email,omitempty" description:"List of email entries in the Subject Alternative Name extension."`
+	SANIP                     Set     `json:"san.ip,omitempty" panther:"ip" description:"List of IP entries in the Subject Alternative Name extension."`
+	BasicConstraintsCA        *bool   `json:"basic_constraints.ca,omitempty" description:"CA flag set or not."`
+	BasicConstraintsPathLen   *uint64 `json:"basic_constraints.path_len,omitempty" description:"Maximum path length."`
+	ZeekMeta
+	ZeekPantherLog
+}
+
+// ZeekX509Parser parses zeek x509 logs
+type ZeekX509Parser struct {
+	// Strict rejects logs with fields that are not part of the schema instead of ignoring them
+	Strict bool
+	// TimeOffset is added to all timestamps of an event to correct the clock skew of a sensor
+	TimeOffset time.Duration
+	// MinTime and MaxTime reject events with a `ts` outside the range with a *TimeRangeError, zero values are unbounded
+	MinTime time.Time
+	MaxTime time.Time
+	// Indicators selects the indicator fields added to events, all indicators are added by default
+	Indicators IndicatorPolicy
+	decoder    logDecoder
+}
+
+var _ parsers.LogParser = (*ZeekX509Parser)(nil)
+
+func (p *ZeekX509Parser) New() parsers.LogParser {
+	return &ZeekX509Parser{
+		Strict:     p.Strict,
+		TimeOffset: p.TimeOffset,
+		MinTime:    p.MinTime,
+		MaxTime:    p.MaxTime,
+		Indicators: p.Indicators,
+	}
+}
+
+// Parse returns the parsed events or nil if parsing failed
+func (p *ZeekX509Parser) Parse(log string) ([]*parsers.PantherLog, error) {
+	zeekX509 := &ZeekX509{}
+
+	ok, err := p.decoder.Decode(log, pathX509, zeekX509, p.Strict, p.TimeOffset, timeRange{p.MinTime, p.MaxTime})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// TSV header directives contain no events
+		return nil, nil
+	}
+
+	zeekX509.updatePantherFields(p)
+	p.Indicators.apply(&zeekX509.ZeekPantherLog)
+
+	if err := parsers.Validator.Struct(zeekX509); err != nil {
+		return nil, err
+	}
+
+	return zeekX509.Logs(), nil
+}
+
+// ParseString parses a log line and returns the results
+func (p *ZeekX509Parser) ParseString(line string) ([]*parsers.Result, error) {
+	return parseString(p, line)
+}
+
+// LogType returns the log type supported by this parser
+func (p *ZeekX509Parser) LogType() string {
+	return TypeZeekX509
+}
+
+func (event *ZeekX509) updatePantherFields(p *ZeekX509Parser) {
+	event.SetCoreFields(p.LogType(), (*timestamp.RFC3339)(event.TS), event)
+
+	for _, name := range event.SANDNS {
+		// Wildcard entries match any subdomain of the domain that follows
+		appendDomainName(&event.ZeekPantherLog, strings.TrimPrefix(name, "*."))
+	}
+	for i := range event.SANIP {
+		appendIPAddressField(&event.ZeekPantherLog, &p.Indicators, "san.ip", &event.SANIP[i])
+	}
+}
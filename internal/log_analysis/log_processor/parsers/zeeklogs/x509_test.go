@@ -0,0 +1,96 @@
+package zeeklogs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/testutil"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/timestamp"
+)
+
+func TestZeekX509(t *testing.T) {
+	// nolint:lll
+	log := `{"ts":1541001600.580233,"id":"FjsOce3qZH1h3C5mJ4","certificate.version":3,"certificate.serial":"0FD078DD48F1A2BD4D0F2BA96B6038FE","certificate.subject":"CN=www.example.org,O=Internet Corporation for Assigned Names and Numbers,L=Los Angeles,ST=California,C=US","certificate.issuer":"CN=DigiCert SHA2 Secure Server CA,O=DigiCert Inc,C=US","certificate.not_valid_before":1543536000.0,"certificate.not_valid_after":1606824000.0,"certificate.key_alg":"rsaEncryption","certificate.sig_alg":"sha256WithRSAEncryption","certificate.key_type":"rsa","certificate.key_length":2048,"certificate.exponent":"65537","san.dns":["www.example.org","example.com","*.example.net"],"san.ip":["93.184.216.34"],"basic_constraints.ca":false}`
+
+	expectedTime := time.Date(2018, 10, 31, 16, 0, 0, 580233000, time.UTC)
+	notValidBefore := time.Date(2018, 11, 30, 0, 0, 0, 0, time.UTC)
+	notValidAfter := time.Date(2020, 12, 1, 12, 0, 0, 0, time.UTC)
+	expectedEvent := &ZeekX509{
+		TS:                        (*Time)(&expectedTime),
+		ID:                        aws.String("FjsOce3qZH1h3C5mJ4"),
+		CertificateVersion:        aws.Uint64(3),
+		CertificateSerial:         aws.String("0FD078DD48F1A2BD4D0F2BA96B6038FE"),
+		CertificateSubject:        aws.String("CN=www.example.org,O=Internet Corporation for Assigned Names and Numbers,L=Los Angeles,ST=California,C=US"),
+		CertificateIssuer:         aws.String("CN=DigiCert SHA2 Secure Server CA,O=DigiCert Inc,C=US"),
+		CertificateNotValidBefore: (*Time)(&notValidBefore),
+		CertificateNotValidAfter:  (*Time)(&notValidAfter),
+		CertificateKeyAlg:         aws.String("rsaEncryption"),
+		CertificateSigAlg:         aws.String("sha256WithRSAEncryption"),
+		CertificateKeyType:        aws.String("rsa"),
+		CertificateKeyLength:      aws.Uint64(2048),
+		CertificateExponent:       aws.String("65537"),
+		SANDNS:                    []string{"www.example.org", "example.com", "*.example.net"},
+		SANIP:                     []string{"93.184.216.34"},
+		BasicConstraintsCA:        aws.Bool(false),
+	}
+
+	// panther fields
+	expectedEvent.PantherLogType = aws.String("Zeek.X509")
+	expectedEvent.AppendAnyDomainNames("www.example.org", "example.com", "example.net")
+	expectedEvent.AppendAnyIPAddress("93.184.216.34")
+	expectedEvent.PantherEventTime = (*timestamp.RFC3339)(&expectedTime)
+	checkZeekX509(t, log, expectedEvent)
+}
+
+func TestZeekX509MappedIPv6(t *testing.T) {
+	// Indicators are canonical while the fields keep the addresses logged by Zeek
+	checkZeekFixture(t, zeekFixture{
+		LogType: TypeZeekX509,
+		// nolint:lll
+		Log: `{"ts":1541001600.580233,"id":"FjsOce3qZH1h3C5mJ4","certificate.subject":"CN=www.example.org","san.dns":["www.example.org"],"san.ip":["::ffff:93.184.216.34","2001:0db8::0001"]}`,
+		Expect: `{
+			"ts": "2018-10-31 16:00:00.580233000",
+			"id": "FjsOce3qZH1h3C5mJ4",
+			"certificate.subject": "CN=www.example.org",
+			"san.dns": ["www.example.org"],
+			"san.ip": ["::ffff:93.184.216.34", "2001:0db8::0001"],
+			"p_log_type": "Zeek.X509",
+			"p_event_time": "2018-10-31 16:00:00.580233000",
+			"p_any_ip_addresses": ["2001:db8::1", "93.184.216.34"],
+			"p_any_domain_names": ["www.example.org"]
+		}`,
+	})
+}
+
+func TestZeekX509Type(t *testing.T) {
+	parser := &ZeekX509Parser{}
+	require.Equal(t, "Zeek.X509", parser.LogType())
+}
+
+func checkZeekX509(t *testing.T, log string, expectedEvent *ZeekX509) {
+	expectedEvent.SetEvent(expectedEvent)
+	parser := &ZeekX509Parser{}
+	logs, err := parser.Parse(log)
+	testutil.EqualPantherLog(t, expectedEvent.Log(), logs, err)
+}
@@ -20,11 +20,89 @@ package zeeklogs
 
 import (
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
-	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 )
 
 const (
-	TypeZeekDNS = "Zeek.DNS"
+	TypeZeekDNS           = "Zeek.DNS"
+	TypeZeekConn          = "Zeek.Conn"
+	TypeZeekHTTP          = "Zeek.HTTP"
+	TypeZeekSSL           = "Zeek.SSL"
+	TypeZeekFiles         = "Zeek.Files"
+	TypeZeekNotice        = "Zeek.Notice"
+	TypeZeekDHCP          = "Zeek.DHCP"
+	TypeZeekX509          = "Zeek.X509"
+	TypeZeekKerberos      = "Zeek.Kerberos"
+	TypeZeekSSH           = "Zeek.SSH"
+	TypeZeekSMTP          = "Zeek.SMTP"
+	TypeZeekWeird         = "Zeek.Weird"
+	TypeZeekDCERPC        = "Zeek.DCE_RPC"
+	TypeZeekFTP           = "Zeek.FTP"
+	TypeZeekIntel         = "Zeek.Intel"
+	TypeZeekNTLM          = "Zeek.NTLM"
+	TypeZeekSMBFiles      = "Zeek.SMB_Files"
+	TypeZeekTunnel        = "Zeek.Tunnel"
+	TypeZeekRDP           = "Zeek.RDP"
+	TypeZeekSoftware      = "Zeek.Software"
+	TypeZeekSyslog        = "Zeek.Syslog"
+	TypeZeekRADIUS        = "Zeek.RADIUS"
+	TypeZeekSIP           = "Zeek.SIP"
+	TypeZeekSNMP          = "Zeek.SNMP"
+	TypeZeekSMBMapping    = "Zeek.SMB_Mapping"
+	TypeZeekMySQL         = "Zeek.MySQL"
+	TypeZeekPE            = "Zeek.PE"
+	TypeZeekDPD           = "Zeek.DPD"
+	TypeZeekIRC           = "Zeek.IRC"
+	TypeZeekSOCKS         = "Zeek.SOCKS"
+	TypeZeekModbus        = "Zeek.Modbus"
+	TypeZeekRFB           = "Zeek.RFB"
+	TypeZeekKnownHosts    = "Zeek.Known_Hosts"
+	TypeZeekKnownServices = "Zeek.Known_Services"
+	TypeZeekDNP3          = "Zeek.DNP3"
+	TypeZeekSignatures    = "Zeek.Signatures"
+	TypeZeekStats         = "Zeek.Stats"
+	TypeZeekCaptureLoss   = "Zeek.Capture_Loss"
+)
+
+// Zeek log paths as they appear in the `#path` header directive of TSV logs
+const (
+	pathDNS           = "dns"
+	pathConn          = "conn"
+	pathHTTP          = "http"
+	pathSSL           = "ssl"
+	pathFiles         = "files"
+	pathNotice        = "notice"
+	pathDHCP          = "dhcp"
+	pathX509          = "x509"
+	pathKerberos      = "kerberos"
+	pathSSH           = "ssh"
+	pathSMTP          = "smtp"
+	pathWeird         = "weird"
+	pathDCERPC        = "dce_rpc"
+	pathFTP           = "ftp"
+	pathIntel         = "intel"
+	pathNTLM          = "ntlm"
+	pathSMBFiles      = "smb_files"
+	pathTunnel        = "tunnel"
+	pathRDP           = "rdp"
+	pathSoftware      = "software"
+	pathSyslog        = "syslog"
+	pathRADIUS        = "radius"
+	pathSIP           = "sip"
+	pathSNMP          = "snmp"
+	pathSMBMapping    = "smb_mapping"
+	pathMySQL         = "mysql"
+	pathPE            = "pe"
+	pathDPD           = "dpd"
+	pathIRC           = "irc"
+	pathSOCKS         = "socks"
+	pathModbus        = "modbus"
+	pathRFB           = "rfb"
+	pathKnownHosts    = "known_hosts"
+	pathKnownServices = "known_services"
+	pathDNP3          = "dnp3"
+	pathSignatures    = "signatures"
+	pathStats         = "stats"
+	pathCaptureLoss   = "capture_loss"
 )
 
 func init() {
@@ -34,6 +112,266 @@ func init() {
 			Description:  `Zeek DNS activity`,
 			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/dns/main.zeek.html#type-DNS::Info`,
 			Schema:       &ZeekDNS{},
-			NewParser:    parsers.AdapterFactory(&ZeekDNSParser{}),
-		})
+			NewParser:    adapterFactory(&ZeekDNSParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekConn,
+			Description:  `Zeek IP, TCP, UDP and ICMP connection activity`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/conn/main.zeek.html#type-Conn::Info`,
+			Schema:       &ZeekConn{},
+			NewParser:    adapterFactory(&ZeekConnParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekHTTP,
+			Description:  `Zeek HTTP request/reply activity`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/http/main.zeek.html#type-HTTP::Info`,
+			Schema:       &ZeekHTTP{},
+			NewParser:    adapterFactory(&ZeekHTTPParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekSSL,
+			Description:  `Zeek SSL/TLS handshake info`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/ssl/main.zeek.html#type-SSL::Info`,
+			Schema:       &ZeekSSL{},
+			NewParser:    adapterFactory(&ZeekSSLParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekFiles,
+			Description:  `Zeek file analysis activity`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/frameworks/files/main.zeek.html#type-Files::Info`,
+			Schema:       &ZeekFiles{},
+			NewParser:    adapterFactory(&ZeekFilesParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekNotice,
+			Description:  `Zeek notices raised by the Zeek notice framework`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/frameworks/notice/main.zeek.html#type-Notice::Info`,
+			Schema:       &ZeekNotice{},
+			NewParser:    adapterFactory(&ZeekNoticeParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekDHCP,
+			Description:  `Zeek DHCP lease activity`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/dhcp/main.zeek.html#type-DHCP::Info`,
+			Schema:       &ZeekDHCP{},
+			NewParser:    adapterFactory(&ZeekDHCPParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekX509,
+			Description:  `Zeek X.509 certificate info`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/files/x509/main.zeek.html#type-X509::Info`,
+			Schema:       &ZeekX509{},
+			NewParser:    adapterFactory(&ZeekX509Parser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekKerberos,
+			Description:  `Zeek Kerberos authentication activity`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/krb/main.zeek.html#type-KRB::Info`,
+			Schema:       &ZeekKerberos{},
+			NewParser:    adapterFactory(&ZeekKerberosParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekSSH,
+			Description:  `Zeek SSH handshakes`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/ssh/main.zeek.html#type-SSH::Info`,
+			Schema:       &ZeekSSH{},
+			NewParser:    adapterFactory(&ZeekSSHParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekSMTP,
+			Description:  `Zeek SMTP transactions`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/smtp/main.zeek.html#type-SMTP::Info`,
+			Schema:       &ZeekSMTP{},
+			NewParser:    adapterFactory(&ZeekSMTPParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekWeird,
+			Description:  `Zeek unexpected network-level activity (protocol anomalies)`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/frameworks/notice/weird.zeek.html#type-Weird::Info`,
+			Schema:       &ZeekWeird{},
+			NewParser:    adapterFactory(&ZeekWeirdParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekDCERPC,
+			Description:  `Zeek DCE/RPC (Windows RPC) operations`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/dce-rpc/main.zeek.html#type-DCE_RPC::Info`,
+			Schema:       &ZeekDCERPC{},
+			NewParser:    adapterFactory(&ZeekDCERPCParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekFTP,
+			Description:  `Zeek FTP commands and replies`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/ftp/info.zeek.html#type-FTP::Info`,
+			Schema:       &ZeekFTP{},
+			NewParser:    adapterFactory(&ZeekFTPParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekIntel,
+			Description:  `Zeek intelligence framework matches`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/frameworks/intel/main.zeek.html#type-Intel::Info`,
+			Schema:       &ZeekIntel{},
+			NewParser:    adapterFactory(&ZeekIntelParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekNTLM,
+			Description:  `Zeek NTLM authentication`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/ntlm/main.zeek.html#type-NTLM::Info`,
+			Schema:       &ZeekNTLM{},
+			NewParser:    adapterFactory(&ZeekNTLMParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekSMBFiles,
+			Description:  `Zeek SMB file operations`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/smb/main.zeek.html#type-SMB::FileInfo`,
+			Schema:       &ZeekSMBFiles{},
+			NewParser:    adapterFactory(&ZeekSMBFilesParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekTunnel,
+			Description:  `Zeek tunneling protocol events`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/frameworks/tunnels/main.zeek.html#type-Tunnel::Info`,
+			Schema:       &ZeekTunnel{},
+			NewParser:    adapterFactory(&ZeekTunnelParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekRDP,
+			Description:  `Zeek RDP remote desktop sessions`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/rdp/main.zeek.html#type-RDP::Info`,
+			Schema:       &ZeekRDP{},
+			NewParser:    adapterFactory(&ZeekRDPParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekSoftware,
+			Description:  `Zeek passively detected software`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/frameworks/software/main.zeek.html#type-Software::Info`,
+			Schema:       &ZeekSoftware{},
+			NewParser:    adapterFactory(&ZeekSoftwareParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekSyslog,
+			Description:  `Zeek Syslog messages seen on the network`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/syslog/main.zeek.html#type-Syslog::Info`,
+			Schema:       &ZeekSyslog{},
+			NewParser:    adapterFactory(&ZeekSyslogParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekRADIUS,
+			Description:  `Zeek RADIUS authentication attempts`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/radius/main.zeek.html#type-RADIUS::Info`,
+			Schema:       &ZeekRADIUS{},
+			NewParser:    adapterFactory(&ZeekRADIUSParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekSIP,
+			Description:  `Zeek SIP (Session Initiation Protocol) requests and responses`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/sip/main.zeek.html#type-SIP::Info`,
+			Schema:       &ZeekSIP{},
+			NewParser:    adapterFactory(&ZeekSIPParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekSNMP,
+			Description:  `Zeek SNMP (Simple Network Management Protocol) sessions`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/snmp/main.zeek.html#type-SNMP::Info`,
+			Schema:       &ZeekSNMP{},
+			NewParser:    adapterFactory(&ZeekSNMPParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekSMBMapping,
+			Description:  `Zeek SMB trees (shares) mapped by clients`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/smb/main.zeek.html#type-SMB::TreeInfo`,
+			Schema:       &ZeekSMBMapping{},
+			NewParser:    adapterFactory(&ZeekSMBMappingParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekMySQL,
+			Description:  `Zeek MySQL commands and responses`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/mysql/main.zeek.html#type-MySQL::Info`,
+			Schema:       &ZeekMySQL{},
+			NewParser:    adapterFactory(&ZeekMySQLParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekPE,
+			Description:  `Zeek portable executable (PE) files seen on the network`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/files/pe/main.zeek.html#type-PE::Info`,
+			Schema:       &ZeekPE{},
+			NewParser:    adapterFactory(&ZeekPEParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekDPD,
+			Description:  `Zeek dynamic protocol detection failures`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/frameworks/dpd/main.zeek.html#type-DPD::Info`,
+			Schema:       &ZeekDPD{},
+			NewParser:    adapterFactory(&ZeekDPDParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekIRC,
+			Description:  `Zeek IRC commands and responses`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/irc/main.zeek.html#type-IRC::Info`,
+			Schema:       &ZeekIRC{},
+			NewParser:    adapterFactory(&ZeekIRCParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekSOCKS,
+			Description:  `Zeek SOCKS proxy requests`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/socks/main.zeek.html#type-SOCKS::Info`,
+			Schema:       &ZeekSOCKS{},
+			NewParser:    adapterFactory(&ZeekSOCKSParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekModbus,
+			Description:  `Zeek Modbus commands and responses`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/modbus/main.zeek.html#type-Modbus::Info`,
+			Schema:       &ZeekModbus{},
+			NewParser:    adapterFactory(&ZeekModbusParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekRFB,
+			Description:  `Zeek RFB (VNC) sessions`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/rfb/main.zeek.html#type-RFB::Info`,
+			Schema:       &ZeekRFB{},
+			NewParser:    adapterFactory(&ZeekRFBParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekKnownHosts,
+			Description:  `Zeek hosts that completed a TCP handshake`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/policy/protocols/conn/known-hosts.zeek.html#type-Known::HostsInfo`,
+			Schema:       &ZeekKnownHosts{},
+			NewParser:    adapterFactory(&ZeekKnownHostsParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekKnownServices,
+			Description:  `Zeek services running on hosts`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/policy/protocols/conn/known-services.zeek.html#type-Known::ServicesInfo`,
+			Schema:       &ZeekKnownServices{},
+			NewParser:    adapterFactory(&ZeekKnownServicesParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekDNP3,
+			Description:  `Zeek DNP3 requests and replies`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/protocols/dnp3/main.zeek.html#type-DNP3::Info`,
+			Schema:       &ZeekDNP3{},
+			NewParser:    adapterFactory(&ZeekDNP3Parser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekSignatures,
+			Description:  `Zeek signature framework matches`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/base/frameworks/signatures/main.zeek.html#type-Signatures::Info`,
+			Schema:       &ZeekSignatures{},
+			NewParser:    adapterFactory(&ZeekSignaturesParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekStats,
+			Description:  `Zeek memory, packet and event processing statistics of sensors`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/policy/misc/stats.zeek.html#type-Stats::Info`,
+			Schema:       &ZeekStats{},
+			NewParser:    adapterFactory(&ZeekStatsParser{}),
+		},
+		logtypes.Config{
+			Name:         TypeZeekCaptureLoss,
+			Description:  `Zeek estimates of packet loss on sensors`,
+			ReferenceURL: `https://docs.zeek.org/en/current/scripts/policy/misc/capture-loss.zeek.html#type-CaptureLoss::Info`,
+			Schema:       &ZeekCaptureLoss{},
+			NewParser:    adapterFactory(&ZeekCaptureLossParser{}),
+		},
+	)
 }